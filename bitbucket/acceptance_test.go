@@ -0,0 +1,150 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	testAccProjectKey = "BB_ACC_PROJECT_KEY"
+)
+
+// testAccSetup records the interactions of an acceptance test with Bitbucket when BB_CASSETTE_MODE=record, or
+// replays them without network or credentials when BB_CASSETTE_MODE=replay.  The env variables named in variables
+// are saved along with the interactions so the replayed configuration matches the recorded one.
+func testAccSetup(t *testing.T, variables ...string) {
+	variables = append([]string{"BB_WORKSPACE"}, variables...)
+	mode := os.Getenv("BB_CASSETTE_MODE")
+	if mode == "" {
+		return
+	}
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	recorder, err := newCassette(path, mode)
+	// A missing recording must not pass unnoticed in replay mode
+	if (err != nil) && (mode == cassetteReplay) && errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("no cassette recorded at %s, record one with BB_CASSETTE_MODE=record", path)
+	}
+	if err != nil {
+		t.Fatalf("unable to load cassette: %v", err)
+	}
+	if mode == cassetteReplay {
+		t.Setenv("TF_ACC", "1")
+		t.Setenv("BB_ACCESS_TOKEN", "replayed")
+		for _, unset := range []string{"BB_CLIENT_ID", "BB_CLIENT_SECRET", "BB_USERNAME", "BB_APP_PASSWORD"} {
+			t.Setenv(unset, "")
+		}
+		//Never wait on recorded rate limits
+		t.Setenv("BB_RETRY_MAX_WAIT", "1")
+		for _, variable := range variables {
+			t.Setenv(variable, recorder.Variables[variable])
+		}
+	} else {
+		for _, variable := range variables {
+			recorder.Variables[variable] = os.Getenv(variable)
+		}
+	}
+	wrapTransport = recorder.Wrap
+	t.Cleanup(func() {
+		wrapTransport = nil
+		err := recorder.Save()
+		if err != nil {
+			t.Errorf("unable to save cassette: %v", err)
+		}
+	})
+}
+
+func testAccPreCheck(t *testing.T, variables ...string) func() {
+	return func() {
+		testPreCheck(t)()
+		for _, variable := range append([]string{"BB_WORKSPACE"}, variables...) {
+			if os.Getenv(variable) == "" {
+				t.Fatalf("%s must be set for acceptance tests", variable)
+			}
+		}
+	}
+}
+
+func testAccConfigRepository(isPrivate bool) string {
+	return fmt.Sprintf(`
+data "bitbucket_project" "Proj" {
+  key = %q
+}
+
+resource "bitbucket_repository" "Repo" {
+  project_id = data.bitbucket_project.Proj.id
+  name       = "tf-acc-test-repo"
+  is_private = %t
+}
+`, os.Getenv(testAccProjectKey), isPrivate)
+}
+
+func TestAccResourceRepository(t *testing.T) {
+	testAccSetup(t, testAccProjectKey)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t, testAccProjectKey),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfigRepository(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_repository.Repo", "id"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "is_private", "true"),
+				),
+			},
+			{
+				Config: testAccConfigRepository(false),
+				Check:  resource.TestCheckResourceAttr("bitbucket_repository.Repo", "is_private", "false"),
+			},
+			{
+				ResourceName:            "bitbucket_repository.Repo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
+
+func TestAccResourceRestriction(t *testing.T) {
+	testAccSetup(t, testAccProjectKey)
+	config := func(value int) string {
+		return testAccConfigRepository(true) + fmt.Sprintf(`
+resource "bitbucket_restriction" "Restrict" {
+  repository_id     = bitbucket_repository.Repo.id
+  kind              = "require_approvals_to_merge"
+  branch_match_kind = "glob"
+  pattern           = "release/*"
+  value             = %d
+}
+`, value)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          testAccPreCheck(t, testAccProjectKey),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_restriction.Restrict", "restriction_id"),
+					resource.TestCheckResourceAttr("bitbucket_restriction.Restrict", "value", "1"),
+				),
+			},
+			{
+				Config: config(2),
+				Check:  resource.TestCheckResourceAttr("bitbucket_restriction.Restrict", "value", "2"),
+			},
+			{
+				ResourceName:            "bitbucket_restriction.Restrict",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
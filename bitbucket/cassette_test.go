@@ -0,0 +1,256 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-http-utils/headers"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+const (
+	cassetteRecord = "record"
+	cassetteReplay = "replay"
+)
+
+// Only these response headers are kept, everything else may identify the session
+var cassetteResponseHeaders = []string{
+	headers.ContentType,
+	headers.Location,
+	client.RetryAfterHeader,
+	client.RateLimitResetHeader,
+	client.RequestIdHeader,
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+// cassette records the HTTP interactions of a client to a file, with credentials scrubbed, and replays them later
+// without any network access
+type cassette struct {
+	Variables    map[string]string     `json:"variables,omitempty"`
+	Interactions []cassetteInteraction `json:"interactions"`
+	path         string
+	mode         string
+	mutex        sync.Mutex
+	used         []bool
+	next         http.RoundTripper
+}
+
+func newCassette(path string, mode string) (*cassette, error) {
+	c := &cassette{
+		Variables:    map[string]string{},
+		Interactions: []cassetteInteraction{},
+		path:         path,
+		mode:         mode,
+	}
+	switch mode {
+	case cassetteRecord:
+		return c, nil
+	case cassetteReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(data, c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+		c.used = make([]bool, len(c.Interactions))
+		return c, nil
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q, expected %s or %s", mode, cassetteRecord, cassetteReplay)
+	}
+}
+
+// Wrap matches TransportConfig.WrapTransport
+func (c *cassette) Wrap(next http.RoundTripper) http.RoundTripper {
+	c.next = next
+	return c
+}
+
+func (c *cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+	request := cassetteRequest{
+		Method: req.Method,
+		Url:    req.URL.String(),
+		Body:   client.RedactBody(requestBody, req.Header.Get(headers.ContentType)),
+	}
+	if c.mode == cassetteReplay {
+		return c.replay(req, request)
+	}
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	response := cassetteResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    map[string]string{},
+		Body:       client.RedactBody(responseBody, resp.Header.Get(headers.ContentType)),
+	}
+	for _, header := range cassetteResponseHeaders {
+		value := resp.Header.Get(header)
+		if value != "" {
+			response.Headers[header] = value
+		}
+	}
+	c.mutex.Lock()
+	c.Interactions = append(c.Interactions, cassetteInteraction{Request: request, Response: response})
+	c.mutex.Unlock()
+	return resp, nil
+}
+
+// replay serves the first unused interaction recorded for the same request, so repeated reads of the same
+// object return their recorded responses in order
+func (c *cassette) replay(req *http.Request, request cassetteRequest) (*http.Response, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i, interaction := range c.Interactions {
+		if c.used[i] || (interaction.Request != request) {
+			continue
+		}
+		c.used[i] = true
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}
+		for key, value := range interaction.Response.Headers {
+			resp.Header.Set(key, value)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction left in cassette %s for %s %s", c.path, request.Method, request.Url)
+}
+
+func (c *cassette) Save() error {
+	if c.mode != cassetteRecord {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(c.path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+func TestCassetteRecordsAndReplays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Header().Set("Content-Type", client.ApplicationJson)
+			w.Write([]byte(`{"access_token":"live-access-token","expires_in":7200}`))
+			return
+		}
+		if r.Header.Get("Authorization") != client.Bearer+" live-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", client.ApplicationJson)
+		w.Header().Set("Set-Cookie", "session=live-cookie")
+		w.Write([]byte(`{"uuid":"{repo}","name":"Recorded"}`))
+	}))
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	newCassetteClient := func(cassette *cassette) *client.Client {
+		transportConfig := client.TransportConfig{RequestTimeout: 5 * time.Second, WrapTransport: cassette.Wrap}
+		c, err := client.NewClient(context.Background(), "ws", server.URL, "", server.URL+"/token", "", "live-client-id", "live-client-secret", "", "", client.NewRetryPolicy(0, 0, 0, 0), transportConfig, 0, false)
+		if err != nil {
+			t.Fatalf("unable to obtain token: %v", err)
+		}
+		return c
+	}
+
+	recorder, err := newCassette(path, cassetteRecord)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recorder.Variables["BB_WORKSPACE"] = "recorded-workspace"
+	c := newCassetteClient(recorder)
+	body, err := c.HttpRequest(context.Background(), false, http.MethodPut, "repositories/ws/repo", nil, nil, bytes.NewBufferString(`{"name":"Recorded"}`))
+	if (err != nil) || !strings.Contains(body.String(), "Recorded") {
+		t.Fatalf("unexpected recorded response: %v, %v", body, err)
+	}
+	err = recorder.Save()
+	if err != nil {
+		t.Fatalf("unable to save cassette: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read cassette: %v", err)
+	}
+	for _, secret := range []string{"live-access-token", "live-client-secret", "live-cookie"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette leaks %q: %s", secret, data)
+		}
+	}
+
+	//Replay with the server gone, so any request that is not served from the cassette fails
+	server.Close()
+	player, err := newCassette(path, cassetteReplay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if player.Variables["BB_WORKSPACE"] != "recorded-workspace" {
+		t.Errorf("variables were not restored: %v", player.Variables)
+	}
+	c = newCassetteClient(player)
+	body, err = c.HttpRequest(context.Background(), false, http.MethodPut, "repositories/ws/repo", nil, nil, bytes.NewBufferString(`{"name":"Recorded"}`))
+	if (err != nil) || !strings.Contains(body.String(), "Recorded") {
+		t.Fatalf("unexpected replayed response: %v, %v", body, err)
+	}
+	_, err = c.HttpRequest(context.Background(), false, http.MethodPut, "repositories/ws/repo", nil, nil, bytes.NewBufferString(`{"name":"Recorded"}`))
+	if (err == nil) || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected the cassette to be exhausted, got %v", err)
+	}
+}
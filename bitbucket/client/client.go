@@ -3,11 +3,12 @@ package client
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-http-utils/headers"
@@ -25,73 +26,153 @@ const (
 )
 
 type Client struct {
-	Workspace    string
+	Workspace      string
+	apiUrl         string
+	internalApiUrl string
+	tokenUrl       string
+	clientId       string
+	clientSecret   string
+	username       string
+	appPassword    string
+	//Guards the token fields below since resources share the client concurrently
+	tokenMutex   sync.Mutex
 	accessToken  string
-	clientId     string
-	clientSecret string
-	numRetries   int
-	retryDelay   int
+	refreshToken string
+	tokenExpiry  time.Time
+	retryPolicy  RetryPolicy
+	pageLength   int
 	httpClient   *http.Client
+	//Only dumped at TRACE level, and always redacted, but still opt-in as bodies can be large
+	logResponseBodies bool
 }
 
-func NewClient(ctx context.Context, workspace string, accessToken string, clientId string, clientSecret string, numRetries int, retryDelay int) (*Client, error) {
+func NewClient(ctx context.Context, workspace string, apiUrl string, internalApiUrl string, tokenUrl string, accessToken string, clientId string, clientSecret string, username string, appPassword string, retryPolicy RetryPolicy, transportConfig TransportConfig, pageLength int, logResponseBodies bool) (*Client, error) {
+	httpClient, err := NewHttpClient(transportConfig)
+	if err != nil {
+		return nil, err
+	}
 	c := &Client{
-		Workspace:    workspace,
-		accessToken:  accessToken,
-		clientId:     clientId,
-		clientSecret: clientSecret,
-		numRetries:   numRetries,
-		retryDelay:   retryDelay,
-		httpClient:   &http.Client{},
+		Workspace:      workspace,
+		apiUrl:         strings.TrimSuffix(apiUrl, "/"),
+		internalApiUrl: strings.TrimSuffix(internalApiUrl, "/"),
+		tokenUrl:       tokenUrl,
+		accessToken:    accessToken,
+		clientId:       clientId,
+		clientSecret:   clientSecret,
+		username:       username,
+		appPassword:    appPassword,
+		retryPolicy:    retryPolicy,
+		pageLength:     pageLength,
+		httpClient:     httpClient,
+
+		logResponseBodies: logResponseBodies,
 	}
 	//Check for client credentials authentication and try to get access token
-	if c.accessToken == "" {
-		tflog.Info(ctx, "Bitbucket API: Obtaining access token...")
-		requestForm := url.Values{
-			"grant_type":    []string{"client_credentials"},
-			"client_id":     []string{c.clientId},
-			"client_secret": []string{c.clientSecret},
-		}
-		req, err := http.NewRequest(http.MethodPost, BBTokenServerUrl, bytes.NewBufferString(requestForm.Encode()))
+	if c.canRefreshAccessToken() {
+		err = c.refreshAccessToken(ctx, "")
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set(headers.ContentType, FormEncoded)
-		requestDump, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			tflog.Info(ctx, "Bitbucket API:", map[string]interface{}{"error": err})
-		} else {
-			tflog.Info(ctx, "Bitbucket API: ", map[string]interface{}{"request": string(requestDump)})
-		}
-		resp, err := c.httpClient.Do(req)
+	}
+	return c, nil
+}
+
+func (c *Client) HttpRequest(ctx context.Context, isInternal bool, method string, path string, query url.Values, headerMap http.Header, body *bytes.Buffer) (*bytes.Buffer, error) {
+	return c.httpRequestUrl(ctx, method, c.RequestPath(isInternal, path), query, headerMap, body)
+}
+
+func (c *Client) httpRequestUrl(ctx context.Context, method string, requestUrl string, query url.Values, headerMap http.Header, body *bytes.Buffer) (*bytes.Buffer, error) {
+	//Keep the body around so that every retry can resend it
+	var requestBody []byte
+	if body != nil {
+		requestBody = body.Bytes()
+	}
+	ctx = c.withMaskedSecrets(ctx)
+	accessToken, err := c.currentAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newHttpRequest(ctx, method, requestUrl, query, headerMap, requestBody, accessToken)
+	if err != nil {
+		return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	c.logRequest(ctx, req, requestBody)
+	try := 0
+	replayed := false
+	var waited time.Duration
+	var resp *http.Response
+	for {
+		resp, err = c.httpClient.Do(req)
 		if err != nil {
 			return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
 		}
-		if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
-			respBody := new(bytes.Buffer)
-			_, err := respBody.ReadFrom(resp.Body)
+		//The token may have been revoked or expired early, so replay once with a fresh one
+		if (resp.StatusCode == http.StatusUnauthorized) && !replayed && c.canRefreshAccessToken() {
+			replayed = true
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			tflog.Info(ctx, "Bitbucket API: Access token rejected, refreshing and replaying request")
+			err = c.refreshAccessToken(ctx, accessToken)
+			if err != nil {
+				return nil, err
+			}
+			accessToken, err = c.currentAccessToken(ctx)
+			if err != nil {
+				return nil, err
+			}
+			req, err = c.newHttpRequest(ctx, method, requestUrl, query, headerMap, requestBody, accessToken)
 			if err != nil {
-				return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
+				return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
 			}
-			return nil, &RequestError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", respBody.String())}
+			continue
+		}
+		if !c.retryPolicy.shouldRetry(resp.StatusCode) {
+			break
 		}
-		//Parse body to extract access_token
-		token := &OauthToken{}
-		err = json.NewDecoder(resp.Body).Decode(token)
+		try++
+		if try > c.retryPolicy.NumRetries {
+			break
+		}
+		delay := c.retryPolicy.delay(try, resp.Header, time.Now())
+		if (c.retryPolicy.MaxTotalWait > 0) && (waited+delay > c.retryPolicy.MaxTotalWait) {
+			tflog.Warn(ctx, "Bitbucket API: Giving up retrying, maximum total wait reached", map[string]any{"status": resp.StatusCode, "waited": waited.String(), "delay": delay.String()})
+			break
+		}
+		//Discard this response so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		tflog.Warn(ctx, "Bitbucket API: Retrying request", map[string]any{"status": resp.StatusCode, "try": try, "delay": delay.String()})
+		err = c.retryPolicy.wait(ctx, delay)
+		if err != nil {
+			return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+		}
+		waited += delay
+		accessToken, err = c.currentAccessToken(ctx)
 		if err != nil {
 			return nil, err
 		}
-		tflog.Info(ctx, "Bitbucket API: Received access token: ", map[string]interface{}{"token": token.AccessToken})
-		//Inject token as access_token for client for all future calls
-		c.accessToken = token.AccessToken
+		req, err = c.newHttpRequest(ctx, method, requestUrl, query, headerMap, requestBody, accessToken)
+		if err != nil {
+			return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+		}
 	}
-	return c, nil
+	defer resp.Body.Close()
+	respBody := new(bytes.Buffer)
+	_, err = respBody.ReadFrom(resp.Body)
+	if err != nil {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
+	}
+	c.logResponse(ctx, req, resp, respBody.Bytes())
+	if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
+		return nil, NewRequestError(resp.StatusCode, resp.Header, respBody.Bytes())
+	}
+	return respBody, nil
 }
 
-func (c *Client) HttpRequest(ctx context.Context, isInternal bool, method string, path string, query url.Values, headerMap http.Header, body *bytes.Buffer) (*bytes.Buffer, error) {
-	req, err := http.NewRequest(method, c.RequestPath(isInternal, path), body)
+func (c *Client) newHttpRequest(ctx context.Context, method string, requestUrl string, query url.Values, headerMap http.Header, body []byte, accessToken string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, bytes.NewReader(body))
 	if err != nil {
-		return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+		return nil, err
 	}
 	//Handle query values
 	if query != nil {
@@ -112,50 +193,27 @@ func (c *Client) HttpRequest(ctx context.Context, isInternal bool, method string
 		}
 	}
 	//Handle authentication
-	if c.accessToken != "" {
-		req.Header.Set(headers.Authorization, Bearer+" "+c.accessToken)
-	}
-	requestDump, err := httputil.DumpRequest(req, true)
-	if err != nil {
-		tflog.Error(ctx, "Bitbucket API:", map[string]any{"error": err})
-	} else {
-		tflog.Error(ctx, "Bitbucket API: ", map[string]any{"request": string(requestDump)})
+	if accessToken != "" {
+		req.Header.Set(headers.Authorization, Bearer+" "+accessToken)
+	} else if c.username != "" {
+		req.SetBasicAuth(c.username, c.appPassword)
 	}
-	try := 0
-	var resp *http.Response
-	for {
-		resp, err = c.httpClient.Do(req)
-		if err != nil {
-			return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
-		}
-		if (resp.StatusCode == http.StatusTooManyRequests) || (resp.StatusCode >= http.StatusInternalServerError) {
-			try++
-			if try >= c.numRetries {
-				break
-			}
-			time.Sleep(time.Duration(c.retryDelay) * time.Second)
-			continue
-		}
-		break
-	}
-	defer resp.Body.Close()
-	respBody := new(bytes.Buffer)
-	_, err = respBody.ReadFrom(resp.Body)
-	if err != nil {
-		return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
-	}
-	if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
-		return nil, &RequestError{StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", respBody.String())}
-	}
-	return respBody, nil
+	return req, nil
 }
 
 func (c *Client) RequestPath(isInternal bool, path string) string {
-	var serverUrl string
+	return fmt.Sprintf("%s/%s", c.serverUrl(isInternal), path)
+}
+
+func (c *Client) serverUrl(isInternal bool) string {
 	if isInternal {
-		serverUrl = BBInternalApiServerUrl
-	} else {
-		serverUrl = BBApiServerUrl
+		if c.internalApiUrl == "" {
+			return BBInternalApiServerUrl
+		}
+		return c.internalApiUrl
+	}
+	if c.apiUrl == "" {
+		return BBApiServerUrl
 	}
-	return fmt.Sprintf("%s/%s", serverUrl, path)
+	return c.apiUrl
 }
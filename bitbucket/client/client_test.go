@@ -0,0 +1,22 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestRequestPathHonorsConfiguredUrls(t *testing.T) {
+	c := &Client{}
+	if actual := c.RequestPath(false, "repositories"); actual != BBApiServerUrl+"/repositories" {
+		t.Errorf("unexpected default api path: %s", actual)
+	}
+	if actual := c.RequestPath(true, "repositories"); actual != BBInternalApiServerUrl+"/repositories" {
+		t.Errorf("unexpected default internal api path: %s", actual)
+	}
+	c = &Client{apiUrl: "https://gateway.example.com/bitbucket/2.0", internalApiUrl: "http://localhost:8080/internal"}
+	if actual := c.RequestPath(false, "repositories"); actual != "https://gateway.example.com/bitbucket/2.0/repositories" {
+		t.Errorf("unexpected configured api path: %s", actual)
+	}
+	if actual := c.RequestPath(true, "repositories"); actual != "http://localhost:8080/internal/repositories" {
+		t.Errorf("unexpected configured internal api path: %s", actual)
+	}
+}
@@ -1,7 +1,5 @@
 package client
 
-import "strings"
-
 const (
 	EnvironmentPath       = "/repositories/%s/%s/environments"
 	EnvironmentPathGet    = EnvironmentPath + "/%s"
@@ -29,15 +27,15 @@ type EnvironmentChange struct {
 	Name         string                  `json:"name,omitempty"`
 	Restrictions EnvironmentRestrictions `json:"restrictions,omitempty"`
 }
-type EnvironmentCollection struct {
-	Values []Environment `json:"values"`
-}
 
 func (e *Environment) EnvironmentEncodeId() string {
 	return e.RepositoryId + IdSeparator + e.Uuid
 }
 
-func EnvironmentDecodeId(s string) (string, string) {
-	tokens := strings.Split(s, IdSeparator)
-	return tokens[0], tokens[1]
+func EnvironmentDecodeId(s string) (string, string, error) {
+	tokens, err := DecodeId(s, "repository_id", "uuid")
+	if err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
 }
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DecodeId splits a composite id made of the given parts, joined by IdSeparator, and rejects anything else
+func DecodeId(s string, parts ...string) ([]string, error) {
+	tokens := strings.Split(s, IdSeparator)
+	if len(tokens) != len(parts) {
+		return nil, fmt.Errorf("invalid id %q: expected %d parts in the form %s", s, len(parts), strings.Join(parts, IdSeparator))
+	}
+	for i, token := range tokens {
+		if strings.TrimSpace(token) == "" {
+			return nil, fmt.Errorf("invalid id %q: %s must not be empty", s, parts[i])
+		}
+	}
+	return tokens, nil
+}
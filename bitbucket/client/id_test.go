@@ -0,0 +1,43 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestDecodeIds(t *testing.T) {
+	tests := []struct {
+		id     string
+		decode func(string) (string, string, error)
+		valid  bool
+	}{
+		{"{repo}:{env}", EnvironmentDecodeId, true},
+		{"{repo}", EnvironmentDecodeId, false},
+		{"", EnvironmentDecodeId, false},
+		{"{repo}:", EnvironmentDecodeId, false},
+		{"{repo}:{env}:extra", EnvironmentDecodeId, false},
+		{"{repo}:{hook}", WebhookDecodeId, true},
+		{":{hook}", WebhookDecodeId, false},
+		{"{repo}:42", RestrictionDecodeId, true},
+		{"{repo}:abc", RestrictionDecodeId, false},
+		{"my-repo", RestrictionDecodeId, false},
+		{"{repo}:557058:0a1b2c3d", RepositoryUserPermissionDecodeId, true},
+		{"{repo}:{user}", RepositoryUserPermissionDecodeId, true},
+		{"{repo}:", RepositoryUserPermissionDecodeId, false},
+		{"{repo}", RepositoryUserPermissionDecodeId, false},
+		{"{repo}:developers", RepositoryGroupPermissionDecodeId, true},
+		{"{repo}:developers:extra", RepositoryGroupPermissionDecodeId, false},
+		{"PROJ:557058:0a1b2c3d", ProjectUserPermissionDecodeId, true},
+		{":{user}", ProjectUserPermissionDecodeId, false},
+		{"PROJ:developers", ProjectGroupPermissionDecodeId, true},
+		{"PROJ", ProjectGroupPermissionDecodeId, false},
+	}
+	for _, test := range tests {
+		repositoryId, id, err := test.decode(test.id)
+		if test.valid && (err != nil) {
+			t.Errorf("%q: unexpected error: %v", test.id, err)
+		}
+		if !test.valid && (err == nil) {
+			t.Errorf("%q: expected error, got %q, %q", test.id, repositoryId, id)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	Redacted = "***REDACTED***"
+)
+
+var sensitiveHeaders = []string{
+	headers.Authorization,
+	headers.ProxyAuthorization,
+	headers.Cookie,
+	headers.SetCookie,
+}
+
+// Keys whose values are always masked, wherever they appear in a JSON or form payload
+var sensitiveFields = []string{
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"password",
+	"secret",
+	"token",
+}
+
+func (c *Client) logRequest(ctx context.Context, req *http.Request, body []byte) {
+	tflog.Debug(ctx, "Bitbucket API: Sending request", map[string]any{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	})
+	tflog.Trace(ctx, "Bitbucket API: Request details", map[string]any{
+		"headers": redactHeaders(req.Header),
+		"body":    RedactBody(body, req.Header.Get(headers.ContentType)),
+	})
+}
+
+func (c *Client) logResponse(ctx context.Context, req *http.Request, resp *http.Response, body []byte) {
+	tflog.Debug(ctx, "Bitbucket API: Received response", map[string]any{
+		"method": req.Method,
+		"url":    req.URL.String(),
+		"status": resp.StatusCode,
+	})
+	if c.logResponseBodies {
+		tflog.Trace(ctx, "Bitbucket API: Response details", map[string]any{
+			"headers": redactHeaders(resp.Header),
+			"body":    RedactBody(body, resp.Header.Get(headers.ContentType)),
+		})
+	}
+}
+
+// withMaskedSecrets masks the configured credentials in anything logged with the returned context, as a
+// last line of defense for values that end up in error messages
+func (c *Client) withMaskedSecrets(ctx context.Context) context.Context {
+	c.tokenMutex.Lock()
+	candidates := []string{c.accessToken, c.refreshToken, c.clientSecret, c.appPassword}
+	c.tokenMutex.Unlock()
+	secrets := []string{}
+	for _, secret := range candidates {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) == 0 {
+		return ctx
+	}
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+	return tflog.MaskMessageStrings(ctx, secrets...)
+}
+
+func redactHeaders(h http.Header) string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, key := range keys {
+		value := strings.Join(h.Values(key), ", ")
+		for _, sensitive := range sensitiveHeaders {
+			if strings.EqualFold(key, sensitive) {
+				value = Redacted
+				break
+			}
+		}
+		lines = append(lines, key+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RedactBody scrubs credentials from a request or response body so it can be logged or recorded
+func RedactBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == FormEncoded {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return Redacted
+		}
+		for key := range values {
+			if isSensitiveField(key) {
+				values[key] = []string{Redacted}
+			}
+		}
+		return values.Encode()
+	}
+	var value any
+	err := json.Unmarshal(body, &value)
+	if err != nil {
+		// Not something we know how to redact, so only log it when it cannot be a credential carrier
+		if mediaType == ApplicationJson || mediaType == "" {
+			return Redacted
+		}
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return Redacted
+	}
+	return string(redacted)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		// Secured pipeline variables only reveal themselves through their secured flag
+		secured, _ := v["secured"].(bool)
+		for key, child := range v {
+			if isSensitiveField(key) || (secured && (key == "value")) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitiveField(key string) bool {
+	for _, sensitive := range sensitiveFields {
+		if strings.EqualFold(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
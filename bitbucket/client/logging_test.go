@@ -0,0 +1,78 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer abc123")
+	h.Set("Content-Type", ApplicationJson)
+	actual := redactHeaders(h)
+	if strings.Contains(actual, "abc123") {
+		t.Errorf("authorization header was not redacted: %s", actual)
+	}
+	if !strings.Contains(actual, "Content-Type: "+ApplicationJson) {
+		t.Errorf("content type header was lost: %s", actual)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		hidden      []string
+		visible     []string
+	}{
+		{
+			name:        "token form",
+			body:        "grant_type=client_credentials&client_id=myid&client_secret=shh",
+			contentType: FormEncoded,
+			hidden:      []string{"shh"},
+			visible:     []string{"myid", "client_credentials"},
+		},
+		{
+			name:        "token response",
+			body:        `{"access_token":"at-9f8e","refresh_token":"rt-7d6c","expires_in":7200}`,
+			contentType: ApplicationJson + "; charset=utf-8",
+			hidden:      []string{"at-9f8e", "rt-7d6c"},
+			visible:     []string{"7200"},
+		},
+		{
+			name:        "webhook secret",
+			body:        `{"url":"https://example.com","secret":"hook-secret","events":["repo:push"]}`,
+			contentType: ApplicationJson,
+			hidden:      []string{"hook-secret"},
+			visible:     []string{"https://example.com", "repo:push"},
+		},
+		{
+			name:        "secured pipeline variables",
+			body:        `{"values":[{"key":"DB_PASSWORD","value":"p4ss","secured":true},{"key":"REGION","value":"eu-west-1","secured":false}]}`,
+			contentType: ApplicationJson,
+			hidden:      []string{"p4ss"},
+			visible:     []string{"DB_PASSWORD", "eu-west-1"},
+		},
+		{
+			name:        "malformed json",
+			body:        `{"access_token":"at-9f8e"`,
+			contentType: ApplicationJson,
+			hidden:      []string{"at-9f8e"},
+		},
+	}
+	for _, test := range tests {
+		actual := RedactBody([]byte(test.body), test.contentType)
+		for _, hidden := range test.hidden {
+			if strings.Contains(actual, hidden) {
+				t.Errorf("%s: %q was not redacted: %s", test.name, hidden, actual)
+			}
+		}
+		for _, visible := range test.visible {
+			if !strings.Contains(actual, visible) {
+				t.Errorf("%s: %q was lost: %s", test.name, visible, actual)
+			}
+		}
+	}
+}
@@ -1,5 +1,22 @@
 package client
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	//Refresh this long before Bitbucket would reject the token, so long running requests are not cut off
+	TokenRefreshWindow = 5 * time.Minute
+)
+
 type OauthToken struct {
 	AccessToken  string `json:"access_token"`
 	TokenType    string `json:"token_type"`
@@ -7,3 +24,101 @@ type OauthToken struct {
 	ExpiresIn    int    `json:"expires_in"`
 	Scopes       string `json:"scopes"`
 }
+
+func (c *Client) canRefreshAccessToken() bool {
+	return (c.clientId != "") && (c.clientSecret != "")
+}
+
+func (c *Client) currentAccessToken(ctx context.Context) (string, error) {
+	c.tokenMutex.Lock()
+	accessToken := c.accessToken
+	expiring := !c.tokenExpiry.IsZero() && time.Now().Add(TokenRefreshWindow).After(c.tokenExpiry)
+	c.tokenMutex.Unlock()
+	if expiring && c.canRefreshAccessToken() {
+		err := c.refreshAccessToken(ctx, accessToken)
+		if err != nil {
+			return "", err
+		}
+		c.tokenMutex.Lock()
+		accessToken = c.accessToken
+		c.tokenMutex.Unlock()
+	}
+	return accessToken, nil
+}
+
+// refreshAccessToken replaces staleToken, unless another request already replaced it while we waited for the lock
+func (c *Client) refreshAccessToken(ctx context.Context, staleToken string) error {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	if c.accessToken != staleToken {
+		return nil
+	}
+	var token *OauthToken
+	var err error
+	if c.refreshToken != "" {
+		tflog.Info(ctx, "Bitbucket API: Refreshing access token...")
+		token, err = c.requestToken(ctx, url.Values{
+			"grant_type":    []string{"refresh_token"},
+			"refresh_token": []string{c.refreshToken},
+			"client_id":     []string{c.clientId},
+			"client_secret": []string{c.clientSecret},
+		})
+		if err != nil {
+			tflog.Warn(ctx, "Bitbucket API: Unable to refresh access token, requesting a new one", map[string]any{"error": err.Error()})
+		}
+	}
+	if token == nil {
+		tflog.Info(ctx, "Bitbucket API: Obtaining access token...")
+		token, err = c.requestToken(ctx, url.Values{
+			"grant_type":    []string{"client_credentials"},
+			"client_id":     []string{c.clientId},
+			"client_secret": []string{c.clientSecret},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	//Inject token as access_token for client for all future calls
+	c.accessToken = token.AccessToken
+	c.refreshToken = token.RefreshToken
+	c.tokenExpiry = time.Time{}
+	if token.ExpiresIn > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return nil
+}
+
+func (c *Client) requestToken(ctx context.Context, requestForm url.Values) (*OauthToken, error) {
+	tokenUrl := c.tokenUrl
+	if tokenUrl == "" {
+		tokenUrl = BBTokenServerUrl
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenUrl, bytes.NewBufferString(requestForm.Encode()))
+	if err != nil {
+		return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	req.Header.Set(headers.ContentType, FormEncoded)
+	c.logRequest(ctx, req, []byte(requestForm.Encode()))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &RequestError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	defer resp.Body.Close()
+	respBody := new(bytes.Buffer)
+	_, err = respBody.ReadFrom(resp.Body)
+	if err != nil {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
+	}
+	c.logResponse(ctx, req, resp, respBody.Bytes())
+	if (resp.StatusCode < http.StatusOK) || (resp.StatusCode >= http.StatusMultipleChoices) {
+		return nil, NewRequestError(resp.StatusCode, resp.Header, respBody.Bytes())
+	}
+	//Parse body to extract access_token
+	token := &OauthToken{}
+	err = json.NewDecoder(respBody).Decode(token)
+	if err != nil {
+		return nil, &RequestError{StatusCode: resp.StatusCode, Err: err}
+	}
+	tflog.Info(ctx, "Bitbucket API: Received access token", map[string]interface{}{"token_type": token.TokenType, "expires_in": token.ExpiresIn, "scopes": token.Scopes})
+	return token, nil
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeTokenServer struct {
+	issued  int32
+	current atomic.Value
+	grants  chan string
+}
+
+func newFakeTokenServer(t *testing.T, expiresIn int) (*fakeTokenServer, *httptest.Server) {
+	fts := &fakeTokenServer{grants: make(chan string, 100)}
+	fts.current.Store("")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/site/oauth2/access_token" {
+			err := r.ParseForm()
+			if err != nil {
+				t.Errorf("unable to parse token request: %v", err)
+			}
+			fts.grants <- r.PostForm.Get("grant_type")
+			token := fmt.Sprintf("token-%d", atomic.AddInt32(&fts.issued, 1))
+			fts.current.Store(token)
+			w.Header().Set("Content-Type", ApplicationJson)
+			fmt.Fprintf(w, `{"access_token":%q,"refresh_token":"refresh","expires_in":%d}`, token, expiresIn)
+			return
+		}
+		if r.Header.Get("Authorization") != Bearer+" "+fts.current.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	return fts, server
+}
+
+func newOauthTestClient(t *testing.T, server *httptest.Server) *Client {
+	c := &Client{
+		apiUrl:       server.URL,
+		tokenUrl:     server.URL + "/site/oauth2/access_token",
+		clientId:     "id",
+		clientSecret: "secret",
+		retryPolicy:  NewRetryPolicy(0, time.Second, time.Minute, 0),
+		httpClient:   &http.Client{},
+	}
+	err := c.refreshAccessToken(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unable to obtain initial token: %v", err)
+	}
+	return c
+}
+
+func TestHttpRequestReplaysOnceAfterUnauthorized(t *testing.T) {
+	fts, server := newFakeTokenServer(t, 7200)
+	defer server.Close()
+	c := newOauthTestClient(t, server)
+	//Simulate the token being revoked on the server side
+	fts.current.Store("revoked")
+	_, err := c.HttpRequest(context.Background(), false, http.MethodGet, "/user", nil, nil, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("expected the request to be replayed with a fresh token, got %v", err)
+	}
+	if fts.issued != 2 {
+		t.Errorf("expected exactly one refresh, got %d tokens issued", fts.issued)
+	}
+	//A token the server keeps rejecting is only replayed once
+	fts.current.Store("revoked")
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/site/oauth2/access_token" {
+			atomic.AddInt32(&fts.issued, 1)
+			fts.grants <- "refresh_token"
+			fmt.Fprint(w, `{"access_token":"still-bad","expires_in":7200}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	_, err = c.HttpRequest(context.Background(), false, http.MethodGet, "/user", nil, nil, &bytes.Buffer{})
+	re, ok := err.(*RequestError)
+	if !ok || (re.StatusCode != http.StatusUnauthorized) {
+		t.Fatalf("expected 401 RequestError, got %v", err)
+	}
+	if fts.issued != 3 {
+		t.Errorf("expected exactly one more refresh, got %d tokens issued", fts.issued)
+	}
+	if grant := <-fts.grants; grant != "client_credentials" {
+		t.Errorf("expected initial client_credentials grant, got %s", grant)
+	}
+	if grant := <-fts.grants; grant != "refresh_token" {
+		t.Errorf("expected refresh_token grant, got %s", grant)
+	}
+}
+
+func TestHttpRequestRefreshesExpiringTokenOnce(t *testing.T) {
+	fts, server := newFakeTokenServer(t, 7200)
+	defer server.Close()
+	c := newOauthTestClient(t, server)
+	//Pretend the token is about to expire
+	c.tokenMutex.Lock()
+	c.tokenExpiry = time.Now().Add(time.Minute)
+	c.tokenMutex.Unlock()
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.HttpRequest(context.Background(), false, http.MethodGet, "/user", nil, nil, &bytes.Buffer{})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	//Concurrent callers holding the same stale token must only trigger a single refresh between them
+	if fts.issued != 2 {
+		t.Errorf("expected exactly one proactive refresh, got %d tokens issued", fts.issued)
+	}
+}
+
+func TestHttpRequestUsesBasicAuthentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || (username != "me@example.com") || (password != "app-password") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	c := &Client{
+		username:    "me@example.com",
+		appPassword: "app-password",
+		retryPolicy: NewRetryPolicy(0, time.Second, time.Minute, 0),
+		httpClient:  &http.Client{},
+	}
+	_, err := c.httpRequestUrl(context.Background(), http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
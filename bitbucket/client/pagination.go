@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	PageLengthParam   = "pagelen"
+	QueryParam        = "q"
+	SortParam         = "sort"
+	DefaultPageLength = 100
+	MaxPageLength     = 100
+)
+
+type Collection[T any] struct {
+	Values  []T    `json:"values"`
+	Page    int    `json:"page,omitempty"`
+	PageLen int    `json:"pagelen,omitempty"`
+	Size    int    `json:"size,omitempty"`
+	Next    string `json:"next,omitempty"`
+}
+
+func HttpRequestAll[T any](ctx context.Context, c *Client, isInternal bool, path string, query url.Values) ([]T, error) {
+	requestQuery := url.Values{}
+	for key, values := range query {
+		requestQuery[key] = append([]string{}, values...)
+	}
+	if (requestQuery.Get(PageLengthParam) == "") && (c.pageLength > 0) {
+		requestQuery.Set(PageLengthParam, strconv.Itoa(c.pageLength))
+	}
+	serverUrl := c.serverUrl(isInternal)
+	requestUrl := c.RequestPath(isInternal, path)
+	retVals := []T{}
+	for requestUrl != "" {
+		body, err := c.httpRequestUrl(ctx, http.MethodGet, requestUrl, requestQuery, nil, &bytes.Buffer{})
+		if err != nil {
+			return nil, err
+		}
+		page := &Collection[T]{}
+		err = json.NewDecoder(body).Decode(page)
+		if err != nil {
+			return nil, err
+		}
+		retVals = append(retVals, page.Values...)
+		requestUrl, err = nextPageUrl(isInternal, serverUrl, page.Next)
+		if err != nil {
+			return nil, err
+		}
+		// The next link already carries the original query
+		requestQuery = nil
+	}
+	return retVals, nil
+}
+
+// nextPageUrl follows a next link through the configured server, since behind a gateway Bitbucket still returns links
+// to its own host
+func nextPageUrl(isInternal bool, serverUrl string, next string) (string, error) {
+	if (next == "") || strings.HasPrefix(next, serverUrl+"/") {
+		return next, nil
+	}
+	bitbucketUrl := BBApiServerUrl
+	if isInternal {
+		bitbucketUrl = BBInternalApiServerUrl
+	}
+	if strings.HasPrefix(next, bitbucketUrl+"/") {
+		return serverUrl + strings.TrimPrefix(next, bitbucketUrl), nil
+	}
+	// Never send credentials to a host other than the one being paginated
+	return "", fmt.Errorf("unexpected next page link outside of %s: %s", serverUrl, next)
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHttpRequestAll(t *testing.T) {
+	queries := []string{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		page := Collection[int]{}
+		switch r.URL.Query().Get("page") {
+		case "":
+			page.Values = []int{1, 2}
+			page.Next = server.URL + "/things?page=2&pagelen=2&q=x"
+		case "2":
+			page.Values = []int{3, 4}
+			page.Next = server.URL + "/things?page=3&pagelen=2&q=x"
+		default:
+			page.Values = []int{5}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+	c := &Client{apiUrl: server.URL, pageLength: 2, httpClient: server.Client()}
+	retVals, err := HttpRequestAll[int](context.Background(), c, false, "things", url.Values{QueryParam: []string{"x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retVals) != 5 {
+		t.Errorf("expected every page to be collected, got %v", retVals)
+	}
+	// The next links already carry the query, so it must not be added twice
+	expected := []string{"pagelen=2&q=x", "page=2&pagelen=2&q=x", "page=3&pagelen=2&q=x"}
+	if strings.Join(queries, " ") != strings.Join(expected, " ") {
+		t.Errorf("unexpected page requests: %v", queries)
+	}
+}
+
+func TestHttpRequestAllThroughGateway(t *testing.T) {
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		page := Collection[string]{Values: []string{r.URL.Query().Get("page")}}
+		if r.URL.Query().Get("page") == "" {
+			// Bitbucket links to itself, not to the gateway in front of it
+			page.Next = BBApiServerUrl + "/repositories/ws?page=2&pagelen=10"
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+	c := &Client{apiUrl: server.URL + "/gateway/2.0", pageLength: 10, httpClient: server.Client()}
+	retVals, err := HttpRequestAll[string](context.Background(), c, false, "repositories/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (len(retVals) != 2) || (retVals[1] != "2") {
+		t.Errorf("unexpected values: %v", retVals)
+	}
+	if (len(paths) != 2) || (paths[1] != "/gateway/2.0/repositories/ws?page=2&pagelen=10") {
+		t.Errorf("next page was not requested through the gateway: %v", paths)
+	}
+}
+
+func TestNextPageUrl(t *testing.T) {
+	tests := []struct {
+		next     string
+		expected string
+		fails    bool
+	}{
+		{next: "", expected: ""},
+		{next: "https://gateway.example.com/bb/repositories/ws?page=2", expected: "https://gateway.example.com/bb/repositories/ws?page=2"},
+		{next: "https://api.bitbucket.org/2.0/repositories/ws?page=2", expected: "https://gateway.example.com/bb/repositories/ws?page=2"},
+		{next: "https://evil.example.com/2.0/repositories/ws?page=2", fails: true},
+		{next: "https://api.bitbucket.org/internal/repositories/ws?page=2", fails: true},
+	}
+	for _, test := range tests {
+		actual, err := nextPageUrl(false, "https://gateway.example.com/bb", test.next)
+		if test.fails != (err != nil) {
+			t.Errorf("%s: unexpected error %v", test.next, err)
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.next, test.expected, actual)
+		}
+	}
+}
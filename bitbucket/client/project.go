@@ -1,10 +1,44 @@
 package client
 
 const (
-	ProjectPath = "/workspaces/%s/projects/%s"
+	ProjectsPath = "/workspaces/%s/projects"
+	ProjectPath  = ProjectsPath + "/%s"
 )
 
+// HasPubliclyVisibleRepos is derived by Bitbucket from the repositories of the project, so it is never written
 type Project struct {
+	Uuid                    string        `json:"uuid,omitempty"`
+	Key                     string        `json:"key"`
+	Name                    string        `json:"name"`
+	Description             string        `json:"description"`
+	IsPrivate               bool          `json:"is_private"`
+	HasPubliclyVisibleRepos bool          `json:"has_publicly_visible_repos,omitempty"`
+	Links                   *ProjectLinks `json:"links,omitempty"`
+	CreatedOn               string        `json:"created_on,omitempty"`
+}
+
+type ProjectLinks struct {
+	Avatar *Link `json:"avatar,omitempty"`
+	Html   *Link `json:"html,omitempty"`
+}
+
+func (p *Project) AvatarUrl() string {
+	if (p.Links == nil) || (p.Links.Avatar == nil) {
+		return ""
+	}
+	return p.Links.Avatar.Href
+}
+
+func (p *Project) HtmlUrl() string {
+	if (p.Links == nil) || (p.Links.Html == nil) {
+		return ""
+	}
+	return p.Links.Html.Href
+}
+
+// ProjectReference is how a repository refers to its project
+type ProjectReference struct {
 	Uuid string `json:"uuid,omitempty"`
 	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
 }
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ProjectUserPermissionPath     = ProjectPath + "/permissions-config/users"
+	ProjectUserPermissionPathGet  = ProjectUserPermissionPath + "/%s"
+	ProjectGroupPermissionPath    = ProjectPath + "/permissions-config/groups"
+	ProjectGroupPermissionPathGet = ProjectGroupPermissionPath + "/%s"
+)
+
+type ProjectUserPermission struct {
+	ProjectKey string   `json:"-"`
+	UserId     string   `json:"-"`
+	Permission string   `json:"permission"`
+	User       *Account `json:"user,omitempty"`
+}
+
+type ProjectGroupPermission struct {
+	ProjectKey string `json:"-"`
+	GroupSlug  string `json:"-"`
+	Permission string `json:"permission"`
+	Group      *Group `json:"group,omitempty"`
+}
+
+func (p *ProjectUserPermission) ProjectUserPermissionEncodeId() string {
+	return p.ProjectKey + IdSeparator + p.UserId
+}
+
+func ProjectUserPermissionDecodeId(s string) (string, string, error) {
+	// Older Atlassian account ids contain the separator themselves
+	projectKey, userId, _ := strings.Cut(s, IdSeparator)
+	if (strings.TrimSpace(projectKey) == "") || (strings.TrimSpace(userId) == "") {
+		return "", "", fmt.Errorf("invalid id %q: expected project_key%suser_id", s, IdSeparator)
+	}
+	return projectKey, userId, nil
+}
+
+func (p *ProjectGroupPermission) ProjectGroupPermissionEncodeId() string {
+	return p.ProjectKey + IdSeparator + p.GroupSlug
+}
+
+func ProjectGroupPermissionDecodeId(s string) (string, string, error) {
+	tokens, err := DecodeId(s, "project_key", "group_slug")
+	if err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestProjectDoesNotWriteDerivedFields(t *testing.T) {
+	data, err := json.Marshal(Project{Key: "PROJ", Name: "Project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "has_publicly_visible_repos") {
+		t.Errorf("has_publicly_visible_repos should not be written: %s", data)
+	}
+	project := Project{}
+	err = json.Unmarshal([]byte(`{"key":"PROJ","has_publicly_visible_repos":true}`), &project)
+	if (err != nil) || !project.HasPubliclyVisibleRepos {
+		t.Errorf("has_publicly_visible_repos should still be read: %v, %v", project, err)
+	}
+}
@@ -1,13 +1,59 @@
 package client
 
 const (
-	RepositoryPath = "/repositories/%s/%s"
+	RepositoriesPath   = "/repositories/%s"
+	RepositoryPath     = RepositoriesPath + "/%s"
+	RepositoryForkPath = RepositoryPath + "/forks"
+	CloneHttps         = "https"
+	CloneSsh           = "ssh"
 )
 
 type Repository struct {
-	Uuid        string  `json:"uuid,omitempty"`
-	Project     Project `json:"project,omitempty"`
-	Name        string  `json:"name,omitempty"`
-	IsPrivate   bool    `json:"is_private"`
-	UseExisting bool    `json:"-"`
+	Uuid        string           `json:"uuid,omitempty"`
+	Slug        string           `json:"slug,omitempty"`
+	FullName    string           `json:"full_name,omitempty"`
+	Workspace   *Workspace       `json:"workspace,omitempty"`
+	Project     ProjectReference `json:"project,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Description string           `json:"description"`
+	Language    string           `json:"language,omitempty"`
+	ForkPolicy  string           `json:"fork_policy,omitempty"`
+	HasIssues   bool             `json:"has_issues"`
+	HasWiki     bool             `json:"has_wiki"`
+	Website     string           `json:"website"`
+	MainBranch  *Branch          `json:"mainbranch,omitempty"`
+	IsPrivate   bool             `json:"is_private"`
+	Links       *RepositoryLinks `json:"links,omitempty"`
+	Parent      *Repository      `json:"parent,omitempty"`
+	UseExisting bool             `json:"-"`
+}
+
+type Workspace struct {
+	Slug string `json:"slug"`
+}
+
+type Branch struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+}
+
+type RepositoryLinks struct {
+	Clone []Link `json:"clone,omitempty"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+	Name string `json:"name,omitempty"`
+}
+
+func (r *Repository) CloneUrl(name string) string {
+	if r.Links == nil {
+		return ""
+	}
+	for _, link := range r.Links.Clone {
+		if link.Name == name {
+			return link.Href
+		}
+	}
+	return ""
 }
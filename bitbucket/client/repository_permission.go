@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RepositoryUserPermissionPath     = "/repositories/%s/%s/permissions-config/users"
+	RepositoryUserPermissionPathGet  = RepositoryUserPermissionPath + "/%s"
+	RepositoryGroupPermissionPath    = "/repositories/%s/%s/permissions-config/groups"
+	RepositoryGroupPermissionPathGet = RepositoryGroupPermissionPath + "/%s"
+	// Accepts either the account id or the UUID of a user
+	UserPath = "/users/%s"
+)
+
+type Account struct {
+	AccountId   string `json:"account_id,omitempty"`
+	Uuid        string `json:"uuid,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+type Group struct {
+	Slug string `json:"slug,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type RepositoryUserPermission struct {
+	RepositoryId string   `json:"-"`
+	UserId       string   `json:"-"`
+	Permission   string   `json:"permission"`
+	User         *Account `json:"user,omitempty"`
+}
+
+type RepositoryGroupPermission struct {
+	RepositoryId string `json:"-"`
+	GroupSlug    string `json:"-"`
+	Permission   string `json:"permission"`
+	Group        *Group `json:"group,omitempty"`
+}
+
+func (p *RepositoryUserPermission) RepositoryUserPermissionEncodeId() string {
+	return p.RepositoryId + IdSeparator + p.UserId
+}
+
+func RepositoryUserPermissionDecodeId(s string) (string, string, error) {
+	// Older Atlassian account ids contain the separator themselves
+	repositoryId, userId, _ := strings.Cut(s, IdSeparator)
+	if (strings.TrimSpace(repositoryId) == "") || (strings.TrimSpace(userId) == "") {
+		return "", "", fmt.Errorf("invalid id %q: expected repository_id%suser_id", s, IdSeparator)
+	}
+	return repositoryId, userId, nil
+}
+
+func (p *RepositoryGroupPermission) RepositoryGroupPermissionEncodeId() string {
+	return p.RepositoryId + IdSeparator + p.GroupSlug
+}
+
+func RepositoryGroupPermissionDecodeId(s string) (string, string, error) {
+	tokens, err := DecodeId(s, "repository_id", "group_slug")
+	if err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
+}
@@ -1,15 +1,113 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	RequestIdHeader = "X-Request-Id"
 )
 
 type RequestError struct {
 	StatusCode int
 	Err        error
+	Message    string
+	Detail     string
+	Fields     map[string][]string
+	RequestId  string
+}
+
+// ErrorEnvelope is the body Bitbucket returns for every failed API call
+type ErrorEnvelope struct {
+	Type  string `json:"type"`
+	Error struct {
+		Message string                     `json:"message"`
+		Detail  json.RawMessage            `json:"detail,omitempty"`
+		Fields  map[string]json.RawMessage `json:"fields,omitempty"`
+	} `json:"error"`
 }
 
 func (r *RequestError) Error() string {
-	return fmt.Sprintf("Status %d: Message: %s: %v", r.StatusCode, http.StatusText(r.StatusCode), r.Err)
+	if r.Message == "" {
+		return fmt.Sprintf("Status %d: Message: %s: %v", r.StatusCode, http.StatusText(r.StatusCode), r.Err)
+	}
+	retVal := fmt.Sprintf("Status %d: Message: %s: %s", r.StatusCode, http.StatusText(r.StatusCode), r.Message)
+	if r.Detail != "" {
+		retVal += ": " + r.Detail
+	}
+	for _, field := range r.FieldNames() {
+		retVal += fmt.Sprintf("\n  %s: %s", field, strings.Join(r.Fields[field], ", "))
+	}
+	if r.RequestId != "" {
+		retVal += fmt.Sprintf("\n(Request Id: %s)", r.RequestId)
+	}
+	return retVal
+}
+
+func (r *RequestError) Unwrap() error {
+	return r.Err
+}
+
+func (r *RequestError) FieldNames() []string {
+	retVal := make([]string, 0, len(r.Fields))
+	for field := range r.Fields {
+		retVal = append(retVal, field)
+	}
+	sort.Strings(retVal)
+	return retVal
+}
+
+// NewRequestError keeps the raw body as Err and, when the body is a Bitbucket error envelope, also fills in its typed fields
+func NewRequestError(statusCode int, header http.Header, body []byte) *RequestError {
+	retVal := &RequestError{
+		StatusCode: statusCode,
+		Err:        fmt.Errorf("%s", body),
+	}
+	if header != nil {
+		retVal.RequestId = header.Get(RequestIdHeader)
+	}
+	envelope := &ErrorEnvelope{}
+	err := json.Unmarshal(body, envelope)
+	if (err != nil) || (envelope.Type != "error") {
+		return retVal
+	}
+	retVal.Message = envelope.Error.Message
+	retVal.Detail = flattenErrorValue(envelope.Error.Detail)
+	if len(envelope.Error.Fields) > 0 {
+		retVal.Fields = map[string][]string{}
+		for field, value := range envelope.Error.Fields {
+			retVal.Fields[field] = flattenErrorValues(value)
+		}
+	}
+	return retVal
+}
+
+// Bitbucket is inconsistent about whether details and field errors are strings, lists of strings or objects
+func flattenErrorValues(raw json.RawMessage) []string {
+	var list []json.RawMessage
+	err := json.Unmarshal(raw, &list)
+	if err != nil {
+		return []string{flattenErrorValue(raw)}
+	}
+	retVal := []string{}
+	for _, item := range list {
+		retVal = append(retVal, flattenErrorValue(item))
+	}
+	return retVal
+}
+
+func flattenErrorValue(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	err := json.Unmarshal(raw, &s)
+	if err == nil {
+		return s
+	}
+	return string(raw)
 }
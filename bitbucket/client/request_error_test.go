@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewRequestErrorParsesEnvelope(t *testing.T) {
+	header := http.Header{}
+	header.Set(RequestIdHeader, "abc-123")
+	body := `{"type":"error","error":{"message":"Bad request","detail":"Invalid environment","fields":{"name":["This field is required."],"environment_type":"Unknown type"}}}`
+	re := NewRequestError(http.StatusBadRequest, header, []byte(body))
+	if (re.Message != "Bad request") || (re.Detail != "Invalid environment") || (re.RequestId != "abc-123") {
+		t.Errorf("unexpected parse: %+v", re)
+	}
+	expected := map[string][]string{
+		"name":             {"This field is required."},
+		"environment_type": {"Unknown type"},
+	}
+	if !reflect.DeepEqual(re.Fields, expected) {
+		t.Errorf("unexpected fields: %v", re.Fields)
+	}
+	message := re.Error()
+	for _, s := range []string{"Status 400", "Bad request", "Invalid environment", "name: This field is required.", "abc-123"} {
+		if !strings.Contains(message, s) {
+			t.Errorf("expected %q in error message: %s", s, message)
+		}
+	}
+	if strings.Contains(message, `"type":"error"`) {
+		t.Errorf("raw envelope leaked into error message: %s", message)
+	}
+}
+
+func TestNewRequestErrorKeepsUnknownBodies(t *testing.T) {
+	re := NewRequestError(http.StatusBadGateway, nil, []byte("<html>Bad Gateway</html>"))
+	if re.Message != "" {
+		t.Errorf("unexpected message: %s", re.Message)
+	}
+	if !strings.Contains(re.Error(), "<html>Bad Gateway</html>") {
+		t.Errorf("raw body was lost: %s", re.Error())
+	}
+}
@@ -1,8 +1,8 @@
 package client
 
 import (
+	"fmt"
 	"strconv"
-	"strings"
 )
 
 const (
@@ -18,17 +18,24 @@ type Restriction struct {
 	BranchType      string `json:"branch_type,omitempty"`
 	Pattern         string `json:"pattern,omitempty"`
 	Value           int    `json:"value,omitempty"`
-	UseExisting     bool   `json:"-"`
-}
-type RestrictionCollection struct {
-	Values []Restriction `json:"values"`
+	// Only the allowlist kinds have users and groups, and an empty allowlist must still be sent to clear it
+	Users       *[]Account `json:"users,omitempty"`
+	Groups      *[]Group   `json:"groups,omitempty"`
+	UseExisting bool       `json:"-"`
 }
 
 func (r *Restriction) RestrictionEncodeId() string {
 	return r.RepositoryId + IdSeparator + strconv.Itoa(r.Id)
 }
 
-func RestrictionDecodeId(s string) (string, string) {
-	tokens := strings.Split(s, IdSeparator)
-	return tokens[0], tokens[1]
+func RestrictionDecodeId(s string) (string, string, error) {
+	tokens, err := DecodeId(s, "repository_id", "restriction_id")
+	if err != nil {
+		return "", "", err
+	}
+	_, err = strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid id %q: restriction_id must be a number", s)
+	}
+	return tokens[0], tokens[1], nil
 }
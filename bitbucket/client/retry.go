@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RetryAfterHeader     = "Retry-After"
+	RateLimitResetHeader = "X-RateLimit-Reset"
+)
+
+type RetryPolicy struct {
+	NumRetries   int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	MaxTotalWait time.Duration
+	random       func() float64
+	sleep        func(context.Context, time.Duration) error
+}
+
+func NewRetryPolicy(numRetries int, baseDelay time.Duration, maxDelay time.Duration, maxTotalWait time.Duration) RetryPolicy {
+	return RetryPolicy{
+		NumRetries:   numRetries,
+		BaseDelay:    baseDelay,
+		MaxDelay:     maxDelay,
+		MaxTotalWait: maxTotalWait,
+	}
+}
+
+func (rp *RetryPolicy) shouldRetry(statusCode int) bool {
+	return (statusCode == http.StatusTooManyRequests) || (statusCode >= http.StatusInternalServerError)
+}
+
+// wait returns early with the context error when the caller is cancelled or times out
+func (rp *RetryPolicy) wait(ctx context.Context, delay time.Duration) error {
+	if rp.sleep != nil {
+		return rp.sleep(ctx, delay)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// delay honors a server provided wait first and only falls back to exponential backoff when there is none
+func (rp *RetryPolicy) delay(try int, header http.Header, now time.Time) time.Duration {
+	retryAfter, ok := parseRetryAfter(header.Get(RetryAfterHeader), now)
+	if ok {
+		return retryAfter
+	}
+	reset, ok := parseRateLimitReset(header.Get(RateLimitResetHeader), now)
+	if ok {
+		return reset
+	}
+	return rp.backoff(try)
+}
+
+// backoff doubles the base delay for every try, caps it at MaxDelay and then picks a random value
+// in the upper half so concurrent resources do not retry in lockstep
+func (rp *RetryPolicy) backoff(try int) time.Duration {
+	if (rp.BaseDelay <= 0) || (try <= 0) {
+		return 0
+	}
+	backoff := rp.BaseDelay
+	for i := 1; i < try; i++ {
+		backoff *= 2
+		if (rp.MaxDelay > 0) && (backoff >= rp.MaxDelay) {
+			break
+		}
+	}
+	if (rp.MaxDelay > 0) && (backoff > rp.MaxDelay) {
+		backoff = rp.MaxDelay
+	}
+	random := rp.random
+	if random == nil {
+		random = rand.Float64
+	}
+	half := backoff / 2
+	return half + time.Duration(random()*float64(backoff-half))
+}
+
+// parseRetryAfter accepts both forms allowed by RFC 9110: delay-seconds and an HTTP-date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if !date.After(now) {
+		return 0, true
+	}
+	return date.Sub(now), true
+}
+
+// parseRateLimitReset accepts the epoch seconds at which the current rate limit window resets
+func parseRateLimitReset(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	reset := time.Unix(epoch, 0)
+	if !reset.After(now) {
+		return 0, true
+	}
+	return reset.Sub(now), true
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(retryPolicy RetryPolicy) (*Client, *[]time.Duration) {
+	waits := &[]time.Duration{}
+	retryPolicy.sleep = func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}
+	retryPolicy.random = func() float64 { return 0.5 }
+	c := &Client{
+		Workspace:   "ws",
+		accessToken: "token",
+		retryPolicy: retryPolicy,
+		httpClient:  &http.Client{},
+	}
+	return c, waits
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"15", 15 * time.Second, true},
+		{" 0 ", 0, true},
+		{"-3", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+	for _, test := range tests {
+		actual, ok := parseRetryAfter(test.value, now)
+		if (actual != test.expected) || (ok != test.ok) {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; expected %v, %v", test.value, actual, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func newTestHeader(keyValues ...string) http.Header {
+	header := http.Header{}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		header.Set(keyValues[i], keyValues[i+1])
+	}
+	return header
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rp := RetryPolicy{BaseDelay: 2 * time.Second, MaxDelay: 10 * time.Second, random: func() float64 { return 1 }}
+	tests := []struct {
+		name     string
+		try      int
+		header   http.Header
+		expected time.Duration
+	}{
+		{"first backoff", 1, http.Header{}, 2 * time.Second},
+		{"second backoff", 2, http.Header{}, 4 * time.Second},
+		{"capped backoff", 5, http.Header{}, 10 * time.Second},
+		{"retry after", 5, newTestHeader(RetryAfterHeader, "42"), 42 * time.Second},
+		{"rate limit reset", 1, newTestHeader(RateLimitResetHeader, strconv.FormatInt(now.Unix()+7, 10)), 7 * time.Second},
+		{"retry after wins", 1, newTestHeader(RetryAfterHeader, "3", RateLimitResetHeader, strconv.FormatInt(now.Unix()+7, 10)), 3 * time.Second},
+	}
+	for _, test := range tests {
+		actual := rp.delay(test.try, test.header, now)
+		if actual != test.expected {
+			t.Errorf("%s: delay = %v; expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	rp := RetryPolicy{BaseDelay: 8 * time.Second, random: func() float64 { return 0 }}
+	if actual := rp.backoff(1); actual != 4*time.Second {
+		t.Errorf("backoff with no jitter = %v; expected %v", actual, 4*time.Second)
+	}
+	rp.random = func() float64 { return 0.5 }
+	if actual := rp.backoff(1); actual != 6*time.Second {
+		t.Errorf("backoff with half jitter = %v; expected %v", actual, 6*time.Second)
+	}
+}
+
+func TestHttpRequestRetriesWithRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d sent body %q", atomic.LoadInt32(&calls)+1, body)
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set(RetryAfterHeader, "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	c, waits := newTestClient(NewRetryPolicy(5, time.Second, time.Minute, time.Hour))
+	body, err := c.httpRequestUrl(context.Background(), http.MethodPost, server.URL, nil, nil, bytes.NewBufferString("payload"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.String() != `{"ok":true}` {
+		t.Errorf("unexpected body: %s", body.String())
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+	if (len(*waits) != 2) || ((*waits)[0] != 5*time.Second) || ((*waits)[1] != 5*time.Second) {
+		t.Errorf("unexpected waits: %v", *waits)
+	}
+}
+
+func TestHttpRequestBacksOffExponentially(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	c, waits := newTestClient(NewRetryPolicy(3, 2*time.Second, time.Minute, time.Hour))
+	_, err := c.httpRequestUrl(context.Background(), http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	re, ok := err.(*RequestError)
+	if !ok || (re.StatusCode != http.StatusBadGateway) {
+		t.Fatalf("expected 502 RequestError, got %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+	expected := []time.Duration{1500 * time.Millisecond, 3 * time.Second, 6 * time.Second}
+	if len(*waits) != len(expected) {
+		t.Fatalf("unexpected waits: %v", *waits)
+	}
+	for i := range expected {
+		if (*waits)[i] != expected[i] {
+			t.Errorf("wait %d = %v; expected %v", i, (*waits)[i], expected[i])
+		}
+	}
+}
+
+func TestHttpRequestWithoutRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	c, waits := newTestClient(NewRetryPolicy(0, time.Second, time.Minute, time.Hour))
+	_, err := c.httpRequestUrl(context.Background(), http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	re, ok := err.(*RequestError)
+	if !ok || (re.StatusCode != http.StatusServiceUnavailable) {
+		t.Fatalf("expected 503 RequestError, got %v", err)
+	}
+	if (calls != 1) || (len(*waits) != 0) {
+		t.Errorf("expected a single call and no waits, got %d calls and %v", calls, *waits)
+	}
+}
+
+func TestHttpRequestStopsAtMaxTotalWait(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(RetryAfterHeader, "40")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	c, waits := newTestClient(NewRetryPolicy(10, time.Second, time.Minute, 100*time.Second))
+	_, err := c.httpRequestUrl(context.Background(), http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	re, ok := err.(*RequestError)
+	if !ok || (re.StatusCode != http.StatusTooManyRequests) {
+		t.Fatalf("expected 429 RequestError, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+	if len(*waits) != 2 {
+		t.Errorf("unexpected waits: %v", *waits)
+	}
+}
+
+func TestHttpRequestDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	c, waits := newTestClient(NewRetryPolicy(5, time.Second, time.Minute, time.Hour))
+	_, err := c.httpRequestUrl(context.Background(), http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	re, ok := err.(*RequestError)
+	if !ok || (re.StatusCode != http.StatusNotFound) {
+		t.Fatalf("expected 404 RequestError, got %v", err)
+	}
+	if (calls != 1) || (len(*waits) != 0) {
+		t.Errorf("expected a single call and no waits, got %d calls and %v", calls, *waits)
+	}
+}
+
+func TestHttpRequestRetryWaitIsCancellable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(RetryAfterHeader, "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	c := &Client{
+		retryPolicy: NewRetryPolicy(5, time.Second, time.Minute, 0),
+		httpClient:  &http.Client{},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.httpRequestUrl(ctx, http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("request was not interrupted, took %v", elapsed)
+	}
+	re, ok := err.(*RequestError)
+	if !ok || !errors.Is(re.Err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded RequestError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestHttpRequestIsCancellable(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	c := &Client{
+		retryPolicy: NewRetryPolicy(5, time.Second, time.Minute, 0),
+		httpClient:  &http.Client{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := c.httpRequestUrl(ctx, http.MethodGet, server.URL, nil, nil, &bytes.Buffer{})
+	re, ok := err.(*RequestError)
+	if !ok || !errors.Is(re.Err, context.Canceled) {
+		t.Fatalf("expected cancelled RequestError, got %v", err)
+	}
+}
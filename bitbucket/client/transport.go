@@ -0,0 +1,77 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type TransportConfig struct {
+	ProxyUrl            string
+	CaCertPem           string
+	ClientCertPem       string
+	ClientKeyPem        string
+	RequestTimeout      time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	//Optional hook to observe or replace every round trip, such as the cassette of the acceptance tests
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+}
+
+func NewHttpClient(config TransportConfig) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if config.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(config.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if config.CaCertPem != "" {
+		//Extend the system roots rather than replacing them, so only the intercepting proxy needs to be trusted explicitly
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(config.CaCertPem)) {
+			return nil, fmt.Errorf("no valid certificates found in CA certificate PEM")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if (config.ClientCertPem != "") || (config.ClientKeyPem != "") {
+		clientCert, err := tls.X509KeyPair([]byte(config.ClientCertPem), []byte(config.ClientKeyPem))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	transport.TLSClientConfig = tlsConfig
+	var roundTripper http.RoundTripper = transport
+	if config.WrapTransport != nil {
+		roundTripper = config.WrapTransport(transport)
+	}
+	return &http.Client{
+		Transport: roundTripper,
+		Timeout:   config.RequestTimeout,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHttpClientTrustsExtraCaCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	httpClient, err := NewHttpClient(TransportConfig{RequestTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = httpClient.Get(server.URL)
+	if err == nil {
+		t.Fatalf("expected the self signed certificate to be rejected without ca_cert_pem")
+	}
+	caCertPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	httpClient, err = NewHttpClient(TransportConfig{CaCertPem: caCertPem, RequestTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the certificate to be trusted with ca_cert_pem, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHttpClientRejectsInvalidPem(t *testing.T) {
+	_, err := NewHttpClient(TransportConfig{CaCertPem: "not a certificate"})
+	if (err == nil) || !strings.Contains(err.Error(), "CA certificate") {
+		t.Errorf("expected CA certificate error, got %v", err)
+	}
+	_, err = NewHttpClient(TransportConfig{ClientCertPem: "not a certificate", ClientKeyPem: "not a key"})
+	if (err == nil) || !strings.Contains(err.Error(), "client certificate") {
+		t.Errorf("expected client certificate error, got %v", err)
+	}
+}
+
+func TestNewHttpClientUsesProxy(t *testing.T) {
+	proxied := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r.URL.String()
+		w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+	httpClient, err := NewHttpClient(TransportConfig{ProxyUrl: proxy.URL, RequestTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	target := "http://bitbucket.invalid/2.0/user"
+	resp, err := httpClient.Get(target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if actual := <-proxied; actual != target {
+		t.Errorf("expected proxy to receive %s, got %s", target, actual)
+	}
+}
+
+func TestNewHttpClientAppliesTimeouts(t *testing.T) {
+	httpClient, err := NewHttpClient(TransportConfig{RequestTimeout: 7 * time.Second, MaxIdleConnsPerHost: 3, IdleConnTimeout: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if httpClient.Timeout != 7*time.Second {
+		t.Errorf("unexpected request timeout: %v", httpClient.Timeout)
+	}
+	transport := httpClient.Transport.(*http.Transport)
+	if (transport.MaxIdleConnsPerHost != 3) || (transport.IdleConnTimeout != time.Minute) {
+		t.Errorf("unexpected idle connection settings: %d, %v", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+}
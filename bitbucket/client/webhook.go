@@ -1,7 +1,5 @@
 package client
 
-import "strings"
-
 const (
 	WebhookPath    = "/repositories/%s/%s/hooks"
 	WebhookPathGet = WebhookPath + "/%s"
@@ -16,15 +14,15 @@ type Webhook struct {
 	Active       bool     `json:"active"`
 	UseExisting  bool     `json:"-"`
 }
-type WebhookCollection struct {
-	Values []Webhook `json:"values"`
-}
 
 func (wh *Webhook) WebhookEncodeId() string {
 	return wh.RepositoryId + IdSeparator + wh.Uuid
 }
 
-func WebhookDecodeId(s string) (string, string) {
-	tokens := strings.Split(s, IdSeparator)
-	return tokens[0], tokens[1]
+func WebhookDecodeId(s string) (string, string, error) {
+	tokens, err := DecodeId(s, "repository_id", "uuid")
+	if err != nil {
+		return "", "", err
+	}
+	return tokens[0], tokens[1], nil
 }
@@ -18,16 +18,45 @@ func dataSourceProject() *schema.Resource {
 		ReadContext: dataSourceProjectRead,
 		Schema: map[string]*schema.Schema{
 			"key": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"contains_repository_name"},
-				AtLeastOneOf:  []string{"key", "contains_repository_name"},
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key", "name", "contains_repository_name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key", "name", "contains_repository_name"},
 			},
 			"contains_repository_name": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ConflictsWith: []string{"key"},
-				AtLeastOneOf:  []string{"key", "contains_repository_name"},
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"key", "name", "contains_repository_name"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_publicly_visible_repos": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"avatar_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"html_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_on": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 		},
 	}
@@ -37,9 +66,14 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
 	key := d.Get("key").(string)
+	name := d.Get("name").(string)
 	containsRepositoryName := d.Get("contains_repository_name").(string)
 	if containsRepositoryName != "" {
-		slug := convertNameToSlug(containsRepositoryName)
+		slug, slugDiags := slugFromName(containsRepositoryName, "contains_repository_name", "Look the project up by key or name instead.")
+		if slugDiags.HasError() {
+			d.SetId("")
+			return slugDiags
+		}
 		requestPath := fmt.Sprintf(client.RepositoryPath, c.Workspace, slug)
 		requestQuery := url.Values{}
 		body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, requestQuery, nil, &bytes.Buffer{})
@@ -47,7 +81,7 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 			d.SetId("")
 			re := err.(*client.RequestError)
 			if re.StatusCode == http.StatusNotFound {
-				return diags
+				return diag.Errorf("no repository named %q (slug %s) in workspace %s", containsRepositoryName, slug, c.Workspace)
 			}
 			return diag.FromErr(err)
 		}
@@ -59,6 +93,33 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 		}
 		key = retVal.Project.Key
 	}
+	if name != "" {
+		// Filter on the server, but only trust exact matches
+		requestPath := fmt.Sprintf(client.ProjectsPath, c.Workspace)
+		requestQuery := url.Values{
+			client.QueryParam: []string{"name = " + bbqlString(name)},
+		}
+		retVals, err := client.HttpRequestAll[client.Project](ctx, c, false, requestPath, requestQuery)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		keys := []string{}
+		for _, project := range retVals {
+			if project.Name == name {
+				keys = append(keys, project.Key)
+			}
+		}
+		if len(keys) == 0 {
+			d.SetId("")
+			return diag.Errorf("no project named %q in workspace %s", name, c.Workspace)
+		}
+		if len(keys) > 1 {
+			d.SetId("")
+			return diag.Errorf("%d projects are named %q in workspace %s, use one of their keys instead: %v", len(keys), name, c.Workspace, keys)
+		}
+		key = keys[0]
+	}
 	requestPath := fmt.Sprintf(client.ProjectPath, c.Workspace, key)
 	requestQuery := url.Values{}
 	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, requestQuery, nil, &bytes.Buffer{})
@@ -66,7 +127,7 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 		d.SetId("")
 		re := err.(*client.RequestError)
 		if re.StatusCode == http.StatusNotFound {
-			return diags
+			return diag.Errorf("no project with key %s in workspace %s", key, c.Workspace)
 		}
 		return diag.FromErr(err)
 	}
@@ -77,6 +138,13 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 	d.Set("key", retVal.Key)
+	d.Set("name", retVal.Name)
+	d.Set("description", retVal.Description)
+	d.Set("is_private", retVal.IsPrivate)
+	d.Set("has_publicly_visible_repos", retVal.HasPubliclyVisibleRepos)
+	d.Set("avatar_url", retVal.AvatarUrl())
+	d.Set("html_url", retVal.HtmlUrl())
+	d.Set("created_on", retVal.CreatedOn)
 	d.SetId(retVal.Uuid)
 	return diags
 }
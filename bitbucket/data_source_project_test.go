@@ -0,0 +1,70 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceProject(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+data "bitbucket_project" "ByRepo" {
+  contains_repository_name = bitbucket_repository.Repo.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucket_project.Proj", "id", testProjectUuid),
+					resource.TestCheckResourceAttr("data.bitbucket_project.Proj", "key", testProjectKey),
+					resource.TestCheckResourceAttr("data.bitbucket_project.ByRepo", "id", testProjectUuid),
+					resource.TestCheckResourceAttr("data.bitbucket_project.ByRepo", "key", testProjectKey),
+					resource.TestCheckResourceAttr("data.bitbucket_project.ByRepo", "name", "Test Project"),
+					resource.TestCheckResourceAttrSet("data.bitbucket_project.ByRepo", "created_on"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceProjectRead(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{"key": testProjectKey})
+	checkDiags(t, dataSourceProjectRead(ctx, d, c))
+	if (d.Id() != testProjectUuid) || (d.Get("name").(string) != "Test Project") || !d.Get("is_private").(bool) || (d.Get("created_on").(string) == "") || (d.Get("html_url").(string) == "") {
+		t.Errorf("unexpected project: %v", d.State())
+	}
+	d = schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{"name": "Other Project"})
+	checkDiags(t, dataSourceProjectRead(ctx, d, c))
+	if (d.Id() != testOtherProjectUuid) || (d.Get("key").(string) != testOtherProjectKey) {
+		t.Errorf("unexpected project by name: %s, %s", d.Id(), d.Get("key"))
+	}
+	for _, missing := range []map[string]interface{}{{"key": "MISSING"}, {"name": "Missing Project"}, {"contains_repository_name": "Missing Repo"}} {
+		d = schema.TestResourceDataRaw(t, dataSourceProject().Schema, missing)
+		if !dataSourceProjectRead(ctx, d, c).HasError() {
+			t.Errorf("expected an error looking up %v", missing)
+		}
+	}
+	r := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	d = schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{"contains_repository_name": "Test Repo"})
+	checkDiags(t, dataSourceProjectRead(ctx, d, c))
+	if (d.Id() != testProjectUuid) || (d.Get("key").(string) != testProjectKey) {
+		t.Errorf("unexpected project: %s, %s", d.Id(), d.Get("key"))
+	}
+	// A name without a slug must not fall through to the list of repositories
+	d = schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{"contains_repository_name": "日本"})
+	diags := dataSourceProjectRead(ctx, d, c)
+	if !diags.HasError() || (d.Id() != "") {
+		t.Errorf("expected a name without a slug to fail, got %s, %v", d.Id(), diags)
+	}
+}
@@ -0,0 +1,125 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+func dataSourceRepositories() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepositoriesRead,
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"query": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"repositories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slug": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"full_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"main_branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_private": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var repositoriesErrorAttributes = map[string]string{
+	"q":    "query",
+	"sort": "sort",
+}
+
+func dataSourceRepositoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	filters := []string{}
+	projectKey := d.Get("project_key").(string)
+	if projectKey != "" {
+		filters = append(filters, "project.key = "+bbqlString(projectKey))
+	}
+	query := d.Get("query").(string)
+	if query != "" {
+		filters = append(filters, "("+query+")")
+	}
+	requestPath := fmt.Sprintf(client.RepositoriesPath, c.Workspace)
+	requestQuery := url.Values{}
+	if len(filters) > 0 {
+		requestQuery.Set(client.QueryParam, strings.Join(filters, " AND "))
+	}
+	sort := d.Get("sort").(string)
+	if sort != "" {
+		requestQuery.Set(client.SortParam, sort)
+	}
+	retVals, err := client.HttpRequestAll[client.Repository](ctx, c, false, requestPath, requestQuery)
+	if err != nil {
+		d.SetId("")
+		return diagFromRequestError(err, repositoriesErrorAttributes)
+	}
+	repositories := []map[string]interface{}{}
+	for _, retVal := range retVals {
+		mainBranch := ""
+		if retVal.MainBranch != nil {
+			mainBranch = retVal.MainBranch.Name
+		}
+		repositories = append(repositories, map[string]interface{}{
+			"uuid":        retVal.Uuid,
+			"slug":        retVal.Slug,
+			"name":        retVal.Name,
+			"full_name":   retVal.FullName,
+			"project_id":  retVal.Project.Uuid,
+			"project_key": retVal.Project.Key,
+			"main_branch": mainBranch,
+			"is_private":  retVal.IsPrivate,
+		})
+	}
+	d.Set("repositories", repositories)
+	d.SetId(c.Workspace + "?" + requestQuery.Encode())
+	return diags
+}
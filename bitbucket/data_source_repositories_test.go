@@ -0,0 +1,80 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceRepositories(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+data "bitbucket_repositories" "Repos" {
+  project_key = "PROJ"
+  depends_on  = [bitbucket_repository.Repo]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitbucket_repositories.Repos", "repositories.#", "1"),
+					resource.TestCheckResourceAttrPair("data.bitbucket_repositories.Repos", "repositories.0.uuid", "bitbucket_repository.Repo", "id"),
+					resource.TestCheckResourceAttr("data.bitbucket_repositories.Repos", "repositories.0.project_key", testProjectKey),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRepositoriesRead(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	for _, repository := range []map[string]interface{}{
+		{"project_id": testProjectUuid, "name": "Alpha", "main_branch": "main"},
+		{"project_id": testProjectUuid, "name": "Bravo", "main_branch": "develop", "is_private": false},
+		{"project_id": testProjectUuid, "name": "Charlie", "main_branch": "main"},
+		{"project_id": testOtherProjectUuid, "name": "Delta", "main_branch": "main"},
+	} {
+		r := schema.TestResourceDataRaw(t, resourceRepository().Schema, repository)
+		checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	}
+	tests := []struct {
+		config   map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{}, []string{"alpha", "bravo", "charlie", "delta"}},
+		{map[string]interface{}{"project_key": testProjectKey, "sort": "-slug"}, []string{"charlie", "bravo", "alpha"}},
+		{map[string]interface{}{"project_key": testProjectKey, "query": `mainbranch.name = "main"`}, []string{"alpha", "charlie"}},
+		{map[string]interface{}{"query": "is_private = false"}, []string{"bravo"}},
+	}
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, dataSourceRepositories().Schema, test.config)
+		checkDiags(t, dataSourceRepositoriesRead(ctx, d, c))
+		repositories := d.Get("repositories").([]interface{})
+		actual := []string{}
+		for _, repository := range repositories {
+			actual = append(actual, repository.(map[string]interface{})["slug"].(string))
+		}
+		if len(actual) != len(test.expected) {
+			t.Errorf("%v: got %v; expected %v", test.config, actual, test.expected)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != test.expected[i] {
+				t.Errorf("%v: got %v; expected %v", test.config, actual, test.expected)
+				break
+			}
+		}
+	}
+	d := schema.TestResourceDataRaw(t, dataSourceRepositories().Schema, map[string]interface{}{"query": "name ~ broken"})
+	if !dataSourceRepositoriesRead(ctx, d, c).HasError() {
+		t.Errorf("expected an invalid query to fail")
+	}
+}
@@ -0,0 +1,144 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+func dataSourceRepository() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepositoryRead,
+		Schema: map[string]*schema.Schema{
+			"slug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"slug", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"slug", "name"},
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"language": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fork_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_issues": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_wiki": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"website": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"main_branch": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"full_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"clone_https": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"clone_ssh": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	slug := d.Get("slug").(string)
+	name := d.Get("name").(string)
+	if name != "" {
+		// Filter on the server, but only trust exact matches
+		requestPath := fmt.Sprintf(client.RepositoriesPath, c.Workspace)
+		requestQuery := url.Values{
+			client.QueryParam: []string{"name = " + bbqlString(name)},
+		}
+		retVals, err := client.HttpRequestAll[client.Repository](ctx, c, false, requestPath, requestQuery)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		slugs := []string{}
+		for _, repository := range retVals {
+			if repository.Name == name {
+				slugs = append(slugs, repository.Slug)
+			}
+		}
+		if len(slugs) == 0 {
+			d.SetId("")
+			return diag.Errorf("no repository named %q in workspace %s", name, c.Workspace)
+		}
+		if len(slugs) > 1 {
+			d.SetId("")
+			return diag.Errorf("%d repositories are named %q in workspace %s, use one of their slugs instead: %v", len(slugs), name, c.Workspace, slugs)
+		}
+		slug = slugs[0]
+	}
+	requestPath := fmt.Sprintf(client.RepositoryPath, c.Workspace, slug)
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		d.SetId("")
+		re := err.(*client.RequestError)
+		if re.StatusCode == http.StatusNotFound {
+			return diag.Errorf("no repository with slug %s in workspace %s", slug, c.Workspace)
+		}
+		return diag.FromErr(err)
+	}
+	retVal := &client.Repository{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	fillResourceDataFromRepository(retVal, d)
+	d.Set("project_key", retVal.Project.Key)
+	d.SetId(retVal.Uuid)
+	return diags
+}
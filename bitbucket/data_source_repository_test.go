@@ -0,0 +1,76 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceRepository(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+data "bitbucket_repository" "BySlug" {
+  slug = bitbucket_repository.Repo.slug
+}
+
+data "bitbucket_repository" "ByName" {
+  name = bitbucket_repository.Repo.name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.bitbucket_repository.BySlug", "id", "bitbucket_repository.Repo", "id"),
+					resource.TestCheckResourceAttrPair("data.bitbucket_repository.BySlug", "clone_ssh", "bitbucket_repository.Repo", "clone_ssh"),
+					resource.TestCheckResourceAttr("data.bitbucket_repository.BySlug", "project_key", testProjectKey),
+					resource.TestCheckResourceAttrPair("data.bitbucket_repository.ByName", "slug", "bitbucket_repository.Repo", "slug"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceRepositoryRead(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	r := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":  testProjectUuid,
+		"name":        "Test Repo",
+		"main_branch": "develop",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	for _, lookup := range []map[string]interface{}{{"slug": "test-repo"}, {"name": "Test Repo"}} {
+		d := schema.TestResourceDataRaw(t, dataSourceRepository().Schema, lookup)
+		checkDiags(t, dataSourceRepositoryRead(ctx, d, c))
+		expected := map[string]interface{}{
+			"slug":        "test-repo",
+			"name":        "Test Repo",
+			"project_id":  testProjectUuid,
+			"project_key": testProjectKey,
+			"main_branch": "develop",
+			"uuid":        r.Id(),
+			"clone_https": r.Get("clone_https"),
+		}
+		if d.Id() != r.Id() {
+			t.Errorf("%v: id = %s; expected %s", lookup, d.Id(), r.Id())
+		}
+		for key, value := range expected {
+			if d.Get(key) != value {
+				t.Errorf("%v: %s = %v; expected %v", lookup, key, d.Get(key), value)
+			}
+		}
+	}
+	for _, missing := range []map[string]interface{}{{"slug": "missing"}, {"name": "Missing Repo"}} {
+		d := schema.TestResourceDataRaw(t, dataSourceRepository().Schema, missing)
+		if !dataSourceRepositoryRead(ctx, d, c).HasError() {
+			t.Errorf("expected an error looking up %v", missing)
+		}
+	}
+}
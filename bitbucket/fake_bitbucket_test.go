@@ -0,0 +1,780 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+const (
+	testWorkspace        = "test-workspace"
+	testProjectKey       = "PROJ"
+	testProjectUuid      = "{00000000-0000-0000-0000-000000000001}"
+	testOtherProjectKey  = "OTHER"
+	testOtherProjectUuid = "{00000000-0000-0000-0000-000000000002}"
+)
+
+// fakeBitbucket is an in-process stand-in for the parts of the Bitbucket API used by the provider. It keeps
+// every object as loosely typed JSON so that it echoes back whatever fields the provider sends.
+type fakeBitbucket struct {
+	t            *testing.T
+	server       *httptest.Server
+	mutex        sync.Mutex
+	counter      int
+	projects     map[string]map[string]any
+	repositories map[string]map[string]any
+	collections  map[string][]map[string]any
+	singletons   map[string]map[string]any
+}
+
+// Accounts and groups known to the workspace, for permissions
+var fakeUsers = []map[string]any{
+	{"type": "user", "account_id": "557058:00000000-0000-0000-0000-00000000aaaa", "uuid": "{00000000-0000-0000-0000-00000000aaaa}", "display_name": "Alice"},
+	{"type": "user", "account_id": "5b10a2844c20165700ede21g", "uuid": "{00000000-0000-0000-0000-00000000bbbb}", "display_name": "Bob"},
+}
+
+var fakeGroups = []map[string]any{
+	{"type": "group", "slug": "developers", "uuid": "{00000000-0000-0000-0000-0000000000d0}", "name": "Developers"},
+	{"type": "group", "slug": "administrators", "uuid": "{00000000-0000-0000-0000-0000000000a0}", "name": "Administrators"},
+}
+
+// Item id field of every collection nested under a repository
+var fakeCollectionIds = map[string]string{
+	"environments":        "uuid",
+	"branch-restrictions": "id",
+	"hooks":               "uuid",
+}
+
+func newFakeBitbucket(t *testing.T) *fakeBitbucket {
+	fb := &fakeBitbucket{
+		t:            t,
+		projects:     map[string]map[string]any{},
+		repositories: map[string]map[string]any{},
+		collections:  map[string][]map[string]any{},
+		singletons:   map[string]map[string]any{},
+	}
+	fb.projects[testProjectKey] = map[string]any{
+		"type":        "project",
+		"uuid":        testProjectUuid,
+		"key":         testProjectKey,
+		"name":        "Test Project",
+		"description": "",
+		"is_private":  true,
+		"created_on":  "2024-01-02T03:04:05.000000+00:00",
+		"links":       map[string]any{"html": map[string]any{"href": "https://bitbucket.org/" + testWorkspace + "/workspace/projects/" + testProjectKey}},
+	}
+	fb.projects[testOtherProjectKey] = map[string]any{
+		"type": "project",
+		"uuid": testOtherProjectUuid,
+		"key":  testOtherProjectKey,
+		"name": "Other Project",
+	}
+	fb.server = httptest.NewServer(http.HandlerFunc(fb.serveHTTP))
+	t.Cleanup(fb.server.Close)
+	return fb
+}
+
+// configureEnv points the provider at the fake, for tests that go through Terraform
+func (fb *fakeBitbucket) configureEnv(t *testing.T) {
+	t.Setenv("BB_WORKSPACE", testWorkspace)
+	t.Setenv("BB_ACCESS_TOKEN", "test-token")
+	t.Setenv("BB_API_URL", fb.server.URL+"/2.0")
+	t.Setenv("BB_INTERNAL_API_URL", fb.server.URL+"/internal")
+	t.Setenv("BB_NUM_RETRIES", "1")
+	t.Setenv("BB_PAGE_LENGTH", "2")
+}
+
+func (fb *fakeBitbucket) client(t *testing.T) *client.Client {
+	retryPolicy := client.NewRetryPolicy(0, 0, 0, 0)
+	c, err := client.NewClient(context.Background(), testWorkspace, fb.server.URL+"/2.0", fb.server.URL+"/internal", "", "test-token", "", "", "", "", retryPolicy, client.TransportConfig{RequestTimeout: 10 * time.Second}, 2, false)
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+	return c
+}
+
+func (fb *fakeBitbucket) newUuid() string {
+	fb.counter++
+	return fmt.Sprintf("{00000000-0000-0000-0000-%012d}", 1000+fb.counter)
+}
+
+func (fb *fakeBitbucket) repository(slugOrUuid string) map[string]any {
+	repository, ok := fb.repositories[slugOrUuid]
+	if ok {
+		return repository
+	}
+	for _, repository := range fb.repositories {
+		if repository["uuid"] == slugOrUuid {
+			return repository
+		}
+	}
+	return nil
+}
+
+func (fb *fakeBitbucket) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	fb.mutex.Lock()
+	defer fb.mutex.Unlock()
+	if r.Header.Get("Authorization") == "" {
+		fb.writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var body map[string]any
+	if (r.Body != nil) && (r.ContentLength != 0) {
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			fb.writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+	segments := []string{}
+	for _, segment := range strings.Split(r.URL.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if (len(segments) == 3) && (segments[0] == "2.0") && (segments[1] == "users") && (r.Method == http.MethodGet) {
+		for _, user := range fakeUsers {
+			if (user["account_id"] == segments[2]) || (user["uuid"] == segments[2]) {
+				fb.writeJson(w, http.StatusOK, user)
+				return
+			}
+		}
+		fb.writeError(w, http.StatusNotFound, "No such user")
+		return
+	}
+	if (len(segments) < 3) || (segments[2] != testWorkspace) {
+		fb.writeError(w, http.StatusNotFound, "Workspace not found")
+		return
+	}
+	api, kind, rest := segments[0], segments[1], segments[3:]
+	switch {
+	case (api == "2.0") && (kind == "workspaces"):
+		fb.serveProjects(w, r, rest, body)
+	case (api == "2.0") && (kind == "repositories"):
+		fb.serveRepositories(w, r, rest, body)
+	case (api == "internal") && (kind == "repositories"):
+		fb.serveInternal(w, r, rest, body)
+	default:
+		fb.writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (fb *fakeBitbucket) serveProjects(w http.ResponseWriter, r *http.Request, rest []string, body map[string]any) {
+	if (len(rest) == 0) || (rest[0] != "projects") {
+		fb.writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	if len(rest) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			values := []map[string]any{}
+			for _, project := range fb.projects {
+				values = append(values, project)
+			}
+			sort.Slice(values, func(i, j int) bool { return values[i]["key"].(string) < values[j]["key"].(string) })
+			fb.writePage(w, r, values)
+		case http.MethodPost:
+			key, _ := body["key"].(string)
+			if fb.projects[key] != nil {
+				fb.writeFieldError(w, "Project with this Owner and Key already exists.", "key", "Project with this Owner and Key already exists.")
+				return
+			}
+			project := map[string]any{"type": "project", "uuid": fb.newUuid(), "description": "", "is_private": true, "created_on": time.Now().UTC().Format(time.RFC3339)}
+			fb.mergeProject(project, body)
+			fb.projects[key] = project
+			fb.writeJson(w, http.StatusCreated, project)
+		default:
+			fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+	project, ok := fb.projects[strings.ToUpper(rest[1])]
+	if ok && (len(rest) >= 4) && (rest[2] == "permissions-config") {
+		fb.servePermissions(w, r, project["uuid"].(string)+"/permissions-config", []string{"read", "write", "create-repo", "admin"}, rest[3:], body)
+		return
+	}
+	if (len(rest) != 2) || !ok {
+		fb.writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		fb.writeJson(w, http.StatusOK, project)
+	case http.MethodPut:
+		oldKey := project["key"].(string)
+		newKey, _ := body["key"].(string)
+		if (newKey != "") && (newKey != oldKey) && (fb.projects[newKey] != nil) {
+			fb.writeFieldError(w, "Project with this Owner and Key already exists.", "key", "Project with this Owner and Key already exists.")
+			return
+		}
+		fb.mergeProject(project, body)
+		delete(fb.projects, oldKey)
+		fb.projects[project["key"].(string)] = project
+		fb.writeJson(w, http.StatusOK, project)
+	case http.MethodDelete:
+		for _, repository := range fb.repositories {
+			reference, _ := repository["project"].(map[string]any)
+			if (reference != nil) && (reference["uuid"] == project["uuid"]) {
+				fb.writeError(w, http.StatusBadRequest, "You must delete or transfer all repositories out of this project before it can be deleted.")
+				return
+			}
+		}
+		delete(fb.projects, project["key"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// mergeProject applies a create or update body, hosting avatars given as data URLs like Bitbucket does
+func (fb *fakeBitbucket) mergeProject(project map[string]any, body map[string]any) {
+	for key, value := range body {
+		if key != "links" {
+			project[key] = value
+		}
+	}
+	links, _ := body["links"].(map[string]any)
+	avatar, _ := links["avatar"].(map[string]any)
+	if avatar != nil {
+		project["links"] = map[string]any{"avatar": map[string]any{"href": fb.server.URL + "/avatars/" + fmt.Sprint(project["uuid"])}}
+	}
+	project["has_publicly_visible_repos"] = false
+	for _, repository := range fb.repositories {
+		reference, _ := repository["project"].(map[string]any)
+		if (reference != nil) && (reference["uuid"] == project["uuid"]) && (repository["is_private"] == false) {
+			project["has_publicly_visible_repos"] = true
+		}
+	}
+}
+
+func (fb *fakeBitbucket) serveRepositories(w http.ResponseWriter, r *http.Request, rest []string, body map[string]any) {
+	if len(rest) == 0 {
+		if r.Method != http.MethodGet {
+			fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		values := []map[string]any{}
+		for _, repository := range fb.repositories {
+			values = append(values, repository)
+		}
+		values, err := fakeQuery(values, r.URL.Query().Get("q"), r.URL.Query().Get("sort"))
+		if err != nil {
+			fb.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		fb.writePage(w, r, values)
+		return
+	}
+	if len(rest) == 1 {
+		fb.serveRepository(w, r, rest[0], body)
+		return
+	}
+	repository := fb.repository(rest[0])
+	if repository == nil {
+		fb.writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+	repositoryUuid := repository["uuid"].(string)
+	if (len(rest) == 2) && (rest[1] == "forks") && (r.Method == http.MethodPost) {
+		fb.serveFork(w, repository, body)
+		return
+	}
+	if (len(rest) == 2) && (rest[1] == "pipelines_config") {
+		fb.serveSingleton(w, r, repositoryUuid+"/pipelines_config", body, map[string]any{"enabled": false})
+		return
+	}
+	if (len(rest) >= 3) && (rest[1] == "permissions-config") {
+		fb.servePermissions(w, r, repositoryUuid+"/permissions-config", []string{"read", "write", "admin"}, rest[2:], body)
+		return
+	}
+	idField, ok := fakeCollectionIds[rest[1]]
+	if !ok {
+		fb.writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	fb.serveCollection(w, r, repositoryUuid+"/"+rest[1], idField, rest[2:], body)
+}
+
+func (fb *fakeBitbucket) serveRepository(w http.ResponseWriter, r *http.Request, slugOrUuid string, body map[string]any) {
+	repository := fb.repository(slugOrUuid)
+	switch r.Method {
+	case http.MethodGet:
+		if repository == nil {
+			fb.writeError(w, http.StatusNotFound, "Repository not found")
+			return
+		}
+		fb.writeJson(w, http.StatusOK, repository)
+	case http.MethodPost:
+		if repository != nil {
+			fb.writeFieldError(w, "Repository with this Slug and Owner already exists.", "name", "Repository with this Slug and Owner already exists.")
+			return
+		}
+		repository = map[string]any{
+			"type":        "repository",
+			"uuid":        fb.newUuid(),
+			"slug":        slugOrUuid,
+			"is_private":  false,
+			"description": "",
+			"language":    "",
+			"fork_policy": "allow_forks",
+			"has_issues":  false,
+			"has_wiki":    false,
+			"website":     nil,
+			"mainbranch":  map[string]any{"type": "branch", "name": "main"},
+		}
+		fb.mergeRepository(repository, body)
+		fb.repositories[slugOrUuid] = repository
+		fb.writeJson(w, http.StatusOK, repository)
+	case http.MethodPut:
+		if repository == nil {
+			fb.writeError(w, http.StatusNotFound, "Repository not found")
+			return
+		}
+		oldSlug := repository["slug"].(string)
+		// Renames move the repository to a new slug unless one is given
+		if (body["name"] != nil) && (body["name"] != repository["name"]) && (body["slug"] == nil) {
+			body["slug"] = convertNameToSlug(body["name"].(string))
+		}
+		if (body["slug"] != nil) && (body["slug"] != oldSlug) && (fb.repositories[body["slug"].(string)] != nil) {
+			fb.writeFieldError(w, "Repository with this Slug and Owner already exists.", "slug", "Repository with this Slug and Owner already exists.")
+			return
+		}
+		fb.mergeRepository(repository, body)
+		delete(fb.repositories, oldSlug)
+		fb.repositories[repository["slug"].(string)] = repository
+		fb.writeJson(w, http.StatusOK, repository)
+	case http.MethodDelete:
+		if repository == nil {
+			fb.writeError(w, http.StatusNotFound, "Repository not found")
+			return
+		}
+		delete(fb.repositories, repository["slug"].(string))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// serveFork copies the parent like Bitbucket does, with the settings given for the fork
+func (fb *fakeBitbucket) serveFork(w http.ResponseWriter, parent map[string]any, body map[string]any) {
+	workspace, _ := body["workspace"].(map[string]any)
+	if (workspace == nil) || (workspace["slug"] != testWorkspace) {
+		fb.writeFieldError(w, "Invalid workspace", "workspace", "Forks must be created in "+testWorkspace)
+		return
+	}
+	name, _ := body["name"].(string)
+	if name == "" {
+		name = parent["name"].(string)
+	}
+	slug, _ := body["slug"].(string)
+	if slug == "" {
+		slug = convertNameToSlug(name)
+	}
+	if fb.repositories[slug] != nil {
+		fb.writeFieldError(w, "Repository with this Slug and Owner already exists.", "name", "Repository with this Slug and Owner already exists.")
+		return
+	}
+	fork := map[string]any{}
+	for key, value := range parent {
+		fork[key] = value
+	}
+	fork["uuid"] = fb.newUuid()
+	fork["slug"] = slug
+	fork["parent"] = map[string]any{"type": "repository", "uuid": parent["uuid"], "full_name": parent["full_name"], "name": parent["name"]}
+	delete(body, "workspace")
+	delete(body, "mainbranch")
+	delete(body, "website")
+	fb.mergeRepository(fork, body)
+	fb.repositories[slug] = fork
+	fb.writeJson(w, http.StatusCreated, fork)
+}
+
+// mergeRepository applies a create or update body, resolving the project reference like Bitbucket does
+func (fb *fakeBitbucket) mergeRepository(repository map[string]any, body map[string]any) {
+	for key, value := range body {
+		if key == "project" {
+			reference, _ := value.(map[string]any)
+			for _, project := range fb.projects {
+				if (project["uuid"] == reference["uuid"]) || (project["key"] == reference["key"]) {
+					repository["project"] = map[string]any{"type": "project", "uuid": project["uuid"], "key": project["key"], "name": project["name"]}
+				}
+			}
+			continue
+		}
+		if key == "language" {
+			value = strings.ToLower(fmt.Sprint(value))
+		}
+		repository[key] = value
+	}
+	fullName := testWorkspace + "/" + fmt.Sprint(repository["slug"])
+	repository["full_name"] = fullName
+	repository["links"] = map[string]any{
+		"clone": []map[string]any{
+			{"name": "https", "href": "https://bitbucket.org/" + fullName + ".git"},
+			{"name": "ssh", "href": "git@bitbucket.org:" + fullName + ".git"},
+		},
+	}
+}
+
+func (fb *fakeBitbucket) serveCollection(w http.ResponseWriter, r *http.Request, key string, idField string, rest []string, body map[string]any) {
+	items := fb.collections[key]
+	fakePrincipals(body)
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			fb.writePage(w, r, items)
+		case http.MethodPost:
+			item := map[string]any{}
+			for k, v := range body {
+				item[k] = v
+			}
+			if idField == "id" {
+				fb.counter++
+				item[idField] = fb.counter
+			} else {
+				item[idField] = fb.newUuid()
+			}
+			fb.collections[key] = append(items, item)
+			fb.writeJson(w, http.StatusCreated, item)
+		default:
+			fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+	index := -1
+	for i, item := range items {
+		if fmt.Sprint(item[idField]) == rest[0] {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		fb.writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	item := items[index]
+	//Environments are updated through a separate changes endpoint that does not return the environment
+	if (len(rest) == 2) && (rest[1] == "changes") && (r.Method == http.MethodPost) {
+		change, _ := body["change"].(map[string]any)
+		for k, v := range change {
+			item[k] = v
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if len(rest) != 1 {
+		fb.writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		fb.writeJson(w, http.StatusOK, item)
+	case http.MethodPut:
+		for k, v := range body {
+			if k != idField {
+				item[k] = v
+			}
+		}
+		fb.writeJson(w, http.StatusOK, item)
+	case http.MethodDelete:
+		fb.collections[key] = append(items[:index:index], items[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// fakePrincipals expands the users and groups referenced by a body into full accounts and groups, like Bitbucket
+// does for branch restrictions
+func fakePrincipals(body map[string]any) {
+	users, ok := body["users"].([]any)
+	if ok {
+		for i, user := range users {
+			for _, known := range fakeUsers {
+				reference, _ := user.(map[string]any)
+				if (reference["account_id"] == known["account_id"]) || (reference["uuid"] == known["uuid"]) {
+					users[i] = known
+				}
+			}
+		}
+	}
+	groups, ok := body["groups"].([]any)
+	if ok {
+		for i, group := range groups {
+			for _, known := range fakeGroups {
+				reference, _ := group.(map[string]any)
+				if reference["slug"] == known["slug"] {
+					groups[i] = known
+				}
+			}
+		}
+	}
+}
+
+// servePermissions serves the users and groups of a permissions-config, where PUT grants and DELETE revokes
+func (fb *fakeBitbucket) servePermissions(w http.ResponseWriter, r *http.Request, key string, levels []string, rest []string, body map[string]any) {
+	kind := rest[0]
+	if (kind != "users") && (kind != "groups") {
+		fb.writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	key = key + "/" + kind
+	items := fb.collections[key]
+	if len(rest) == 1 {
+		if r.Method != http.MethodGet {
+			fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		fb.writePage(w, r, items)
+		return
+	}
+	var principal map[string]any
+	if kind == "users" {
+		for _, user := range fakeUsers {
+			if (user["account_id"] == rest[1]) || (user["uuid"] == rest[1]) {
+				principal = user
+			}
+		}
+	} else {
+		for _, group := range fakeGroups {
+			if group["slug"] == rest[1] {
+				principal = group
+			}
+		}
+	}
+	if principal == nil {
+		fb.writeError(w, http.StatusNotFound, "No such "+strings.TrimSuffix(kind, "s"))
+		return
+	}
+	principalField := strings.TrimSuffix(kind, "s")
+	index := -1
+	for i, item := range items {
+		if item[principalField].(map[string]any)["uuid"] == principal["uuid"] {
+			index = i
+		}
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if index < 0 {
+			fb.writeError(w, http.StatusNotFound, "Permission not found")
+			return
+		}
+		fb.writeJson(w, http.StatusOK, items[index])
+	case http.MethodPut:
+		permission, _ := body["permission"].(string)
+		if !slices.Contains(levels, permission) {
+			fb.writeFieldError(w, "Invalid permission", "permission", permission+" is not a valid permission")
+			return
+		}
+		item := map[string]any{"type": "permission", "permission": permission, principalField: principal}
+		if index < 0 {
+			fb.collections[key] = append(items, item)
+			fb.writeJson(w, http.StatusCreated, item)
+			return
+		}
+		items[index] = item
+		fb.writeJson(w, http.StatusOK, item)
+	case http.MethodDelete:
+		if index < 0 {
+			fb.writeError(w, http.StatusNotFound, "Permission not found")
+			return
+		}
+		fb.collections[key] = append(items[:index:index], items[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (fb *fakeBitbucket) serveSingleton(w http.ResponseWriter, r *http.Request, key string, body map[string]any, initial map[string]any) {
+	item, ok := fb.singletons[key]
+	if !ok {
+		item = initial
+		fb.singletons[key] = item
+	}
+	switch r.Method {
+	case http.MethodGet:
+		fb.writeJson(w, http.StatusOK, item)
+	case http.MethodPut, http.MethodPost:
+		for k, v := range body {
+			item[k] = v
+		}
+		fb.writeJson(w, http.StatusOK, item)
+	default:
+		fb.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (fb *fakeBitbucket) serveInternal(w http.ResponseWriter, r *http.Request, rest []string, body map[string]any) {
+	if (len(rest) != 3) || (rest[1] != "pipelines-config") || (rest[2] != "dynamic-pipelines-provider") {
+		fb.writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	repository := fb.repository(rest[0])
+	if repository == nil {
+		fb.writeError(w, http.StatusNotFound, "Repository not found")
+		return
+	}
+	fb.serveSingleton(w, r, repository["uuid"].(string)+"/dynamic-pipelines-provider", body, map[string]any{"appAri": ""})
+}
+
+// fakeQuery supports the subset of the Bitbucket query language made of `field = "value"` terms joined by AND,
+// optionally parenthesized, and sorting on one field
+func fakeQuery(values []map[string]any, q string, sortField string) ([]map[string]any, error) {
+	term := regexp.MustCompile(`^\(*\s*([a-z_.]+)\s*=\s*("(?:[^"\\]|\\.)*"|true|false)\s*\)*$`)
+	filtered := values
+	if strings.TrimSpace(q) != "" {
+		filtered = []map[string]any{}
+		type condition struct {
+			field string
+			value string
+		}
+		conditions := []condition{}
+		for _, part := range strings.Split(q, " AND ") {
+			match := term.FindStringSubmatch(strings.TrimSpace(part))
+			if match == nil {
+				return nil, fmt.Errorf("Invalid query: %s", part)
+			}
+			value, err := strconv.Unquote(match[2])
+			if err != nil {
+				value = match[2]
+			}
+			conditions = append(conditions, condition{match[1], value})
+		}
+		for _, item := range values {
+			matches := true
+			for _, condition := range conditions {
+				if fmt.Sprint(fakeField(item, condition.field)) != condition.value {
+					matches = false
+				}
+			}
+			if matches {
+				filtered = append(filtered, item)
+			}
+		}
+	}
+	descending := strings.HasPrefix(sortField, "-")
+	sortField = strings.TrimPrefix(sortField, "-")
+	if sortField == "" {
+		sortField = "slug"
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := fmt.Sprint(fakeField(filtered[i], sortField)), fmt.Sprint(fakeField(filtered[j], sortField))
+		if descending {
+			return b < a
+		}
+		return a < b
+	})
+	return filtered, nil
+}
+
+func fakeField(item map[string]any, field string) any {
+	var value any = item
+	for _, name := range strings.Split(field, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
+
+// writePage serves one page of values, honoring page and pagelen and linking to the next page like Bitbucket
+func (fb *fakeBitbucket) writePage(w http.ResponseWriter, r *http.Request, values []map[string]any) {
+	query := r.URL.Query()
+	pageLen, err := strconv.Atoi(query.Get("pagelen"))
+	if (err != nil) || (pageLen <= 0) {
+		pageLen = 10
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if (err != nil) || (page <= 0) {
+		page = 1
+	}
+	start := (page - 1) * pageLen
+	end := start + pageLen
+	if start > len(values) {
+		start = len(values)
+	}
+	if end > len(values) {
+		end = len(values)
+	}
+	retVal := map[string]any{
+		"values":  values[start:end],
+		"page":    page,
+		"pagelen": pageLen,
+		"size":    len(values),
+	}
+	if end < len(values) {
+		next := url.Values{}
+		for k, v := range query {
+			next[k] = v
+		}
+		next.Set("page", strconv.Itoa(page+1))
+		retVal["next"] = fb.server.URL + r.URL.Path + "?" + next.Encode()
+	}
+	fb.writeJson(w, http.StatusOK, retVal)
+}
+
+func (fb *fakeBitbucket) writeJson(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", client.ApplicationJson)
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		fb.t.Errorf("unable to encode fake response: %v", err)
+	}
+}
+
+func (fb *fakeBitbucket) writeError(w http.ResponseWriter, statusCode int, message string) {
+	fb.writeJson(w, statusCode, map[string]any{"type": "error", "error": map[string]any{"message": message}})
+}
+
+func (fb *fakeBitbucket) writeFieldError(w http.ResponseWriter, message string, field string, fieldMessage string) {
+	fb.writeJson(w, http.StatusBadRequest, map[string]any{"type": "error", "error": map[string]any{"message": message, "fields": map[string]any{field: []string{fieldMessage}}}})
+}
+
+// testProviderFactories and testPreCheck drive the resource.UnitTest suites, which need a Terraform CLI
+var testProviderFactories = map[string]func() (*schema.Provider, error){
+	"bitbucket": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func testPreCheck(t *testing.T) func() {
+	return func() {
+		if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+			return
+		}
+		_, err := exec.LookPath("terraform")
+		if err != nil {
+			t.Skip("terraform CLI not found, install it or set TF_ACC_TERRAFORM_PATH to run this test offline")
+		}
+	}
+}
+
+const testConfigRepository = `
+data "bitbucket_project" "Proj" {
+  key = "PROJ"
+}
+
+resource "bitbucket_repository" "Repo" {
+  project_id = data.bitbucket_project.Proj.id
+  name       = "Test Repo"
+}
+`
@@ -2,12 +2,18 @@ package bitbucket
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
 )
 
+// wrapTransport lets tests record or replay every HTTP interaction of the provider
+var wrapTransport func(http.RoundTripper) http.RoundTripper
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -16,18 +22,36 @@ func Provider() *schema.Provider {
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("BB_WORKSPACE", nil),
 			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_API_URL", client.BBApiServerUrl),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"internal_api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_INTERNAL_API_URL", client.BBInternalApiServerUrl),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"token_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_TOKEN_URL", client.BBTokenServerUrl),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
 			"access_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Sensitive:     true,
 				DefaultFunc:   schema.EnvDefaultFunc("BB_ACCESS_TOKEN", nil),
-				ConflictsWith: []string{"client_id", "client_secret"},
+				ConflictsWith: []string{"client_id", "client_secret", "username", "app_password"},
 			},
 			"client_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("BB_CLIENT_ID", nil),
-				ConflictsWith: []string{"access_token"},
+				ConflictsWith: []string{"access_token", "username", "app_password"},
 				RequiredWith:  []string{"client_secret"},
 			},
 			"client_secret": {
@@ -35,30 +59,126 @@ func Provider() *schema.Provider {
 				Optional:      true,
 				Sensitive:     true,
 				DefaultFunc:   schema.EnvDefaultFunc("BB_CLIENT_SECRET", nil),
-				ConflictsWith: []string{"access_token"},
+				ConflictsWith: []string{"access_token", "username", "app_password"},
 				RequiredWith:  []string{"client_id"},
 			},
+			"username": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("BB_USERNAME", nil),
+				ConflictsWith: []string{"access_token", "client_id", "client_secret"},
+				RequiredWith:  []string{"app_password"},
+			},
+			"app_password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("BB_APP_PASSWORD", nil),
+				ConflictsWith: []string{"access_token", "client_id", "client_secret"},
+				RequiredWith:  []string{"username"},
+			},
 			"num_retries": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("BB_NUM_RETRIES", 3),
 			},
 			"retry_delay": {
-				Type:        schema.TypeInt,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_RETRY_DELAY", 2),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_delay": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_RETRY_MAX_DELAY", 60),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_RETRY_MAX_WAIT", 300),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BB_CA_CERT_PEM", nil),
+			},
+			"client_cert_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_CLIENT_CERT_PEM", nil),
+				RequiredWith: []string{"client_key_pem"},
+			},
+			"client_key_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_CLIENT_KEY_PEM", nil),
+				RequiredWith: []string{"client_cert_pem"},
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_REQUEST_TIMEOUT", 60),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_MAX_IDLE_CONNS", 100),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_idle_conns_per_host": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_MAX_IDLE_CONNS_PER_HOST", 10),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"idle_conn_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_IDLE_CONN_TIMEOUT", 90),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"log_response_bodies": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("BB_RETRY_DELAY", 30),
+				DefaultFunc: schema.EnvDefaultFunc("BB_LOG_RESPONSE_BODIES", false),
+			},
+			"page_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("BB_PAGE_LENGTH", client.DefaultPageLength),
+				ValidateFunc: validation.IntBetween(1, client.MaxPageLength),
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"bitbucket_dynamic_pipelines_provider": resourceDynamicPipelinesProvider(),
-			"bitbucket_environment":                resourceEnvironment(),
-			"bitbucket_pipelines_config":           resourcePipelinesConfig(),
-			"bitbucket_repository":                 resourceRepository(),
-			"bitbucket_restriction":                resourceRestriction(),
-			"bitbucket_webhook":                    resourceWebhook(),
+			"bitbucket_dynamic_pipelines_provider":  resourceDynamicPipelinesProvider(),
+			"bitbucket_environment":                 resourceEnvironment(),
+			"bitbucket_pipelines_config":            resourcePipelinesConfig(),
+			"bitbucket_project":                     resourceProject(),
+			"bitbucket_project_group_permission":    resourceProjectGroupPermission(),
+			"bitbucket_project_user_permission":     resourceProjectUserPermission(),
+			"bitbucket_repository":                  resourceRepository(),
+			"bitbucket_repository_fork":             resourceRepositoryFork(),
+			"bitbucket_repository_group_permission": resourceRepositoryGroupPermission(),
+			"bitbucket_repository_permissions":      resourceRepositoryPermissions(),
+			"bitbucket_repository_user_permission":  resourceRepositoryUserPermission(),
+			"bitbucket_restriction":                 resourceRestriction(),
+			"bitbucket_webhook":                     resourceWebhook(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"bitbucket_project": dataSourceProject(),
+			"bitbucket_project":      dataSourceProject(),
+			"bitbucket_repository":   dataSourceRepository(),
+			"bitbucket_repositories": dataSourceRepositories(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -66,19 +186,43 @@ func Provider() *schema.Provider {
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	workspace := d.Get("workspace").(string)
+	apiUrl := d.Get("api_url").(string)
+	internalApiUrl := d.Get("internal_api_url").(string)
+	tokenUrl := d.Get("token_url").(string)
 	accessToken := d.Get("access_token").(string)
 	clientId := d.Get("client_id").(string)
 	clientSecret := d.Get("client_secret").(string)
+	username := d.Get("username").(string)
+	appPassword := d.Get("app_password").(string)
 	numRetries := d.Get("num_retries").(int)
 	retryDelay := d.Get("retry_delay").(int)
+	retryMaxDelay := d.Get("retry_max_delay").(int)
+	retryMaxWait := d.Get("retry_max_wait").(int)
+	transportConfig := client.TransportConfig{
+		ProxyUrl:            d.Get("proxy_url").(string),
+		CaCertPem:           d.Get("ca_cert_pem").(string),
+		ClientCertPem:       d.Get("client_cert_pem").(string),
+		ClientKeyPem:        d.Get("client_key_pem").(string),
+		RequestTimeout:      time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		MaxIdleConns:        d.Get("max_idle_conns").(int),
+		MaxIdleConnsPerHost: d.Get("max_idle_conns_per_host").(int),
+		IdleConnTimeout:     time.Duration(d.Get("idle_conn_timeout").(int)) * time.Second,
+		WrapTransport:       wrapTransport,
+	}
+	pageLength := d.Get("page_length").(int)
+	logResponseBodies := d.Get("log_response_bodies").(bool)
 
 	//Check for valid authentication
-	if (clientId == "") && (clientSecret == "") && (accessToken == "") {
-		return nil, diag.Errorf("You must specify either client_id/client_secret for Client Credentials Authentication or access_token")
+	if (clientId == "") && (clientSecret == "") && (accessToken == "") && (username == "") && (appPassword == "") {
+		return nil, diag.Errorf("You must specify either client_id/client_secret for Client Credentials Authentication, username/app_password for Basic Authentication or access_token")
+	}
+	if (username == "") != (appPassword == "") {
+		return nil, diag.Errorf("You must specify both username and app_password for Basic Authentication")
 	}
 
 	var diags diag.Diagnostics
-	c, err := client.NewClient(ctx, workspace, accessToken, clientId, clientSecret, numRetries, retryDelay)
+	retryPolicy := client.NewRetryPolicy(numRetries, time.Duration(retryDelay)*time.Second, time.Duration(retryMaxDelay)*time.Second, time.Duration(retryMaxWait)*time.Second)
+	c, err := client.NewClient(ctx, workspace, apiUrl, internalApiUrl, tokenUrl, accessToken, clientId, clientSecret, username, appPassword, retryPolicy, transportConfig, pageLength, logResponseBodies)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -0,0 +1,49 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProvider(t *testing.T) {
+	err := Provider().InternalValidate()
+	if err != nil {
+		t.Fatalf("invalid provider: %v", err)
+	}
+}
+
+func checkDiags(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+// testPlan diffs the state of d against config the way Terraform plans a change, raw config included
+func testPlan(t *testing.T, r *schema.Resource, d *schema.ResourceData, config map[string]interface{}, m interface{}) *terraform.InstanceDiff {
+	t.Helper()
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	rawConfig, err := ctyjson.Unmarshal(data, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	state := d.State()
+	state.RawConfig = rawConfig
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff == nil {
+		return &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{}}
+	}
+	return diff
+}
@@ -22,6 +22,7 @@ func resourceDynamicPipelinesProvider() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
 				Type:     schema.TypeString,
@@ -36,6 +37,10 @@ func resourceDynamicPipelinesProvider() *schema.Resource {
 	}
 }
 
+var dynamicPipelinesProviderErrorAttributes = map[string]string{
+	"appAri": "provider_id",
+}
+
 func fillDynamicPipelinesProvider(c *client.DynamicPipelinesProvider, d *schema.ResourceData) {
 	c.RepositoryId = d.Get("repository_id").(string)
 	c.AppAri = d.Get("provider_id").(string)
@@ -65,7 +70,7 @@ func resourceDynamicPipelinesProviderCreate(ctx context.Context, d *schema.Resou
 	body, err := c.HttpRequest(ctx, true, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return diagFromRequestError(err, dynamicPipelinesProviderErrorAttributes)
 	}
 	retVal := &client.DynamicPipelinesProvider{}
 	err = json.NewDecoder(body).Decode(retVal)
@@ -119,7 +124,7 @@ func resourceDynamicPipelinesProviderUpdate(ctx context.Context, d *schema.Resou
 	}
 	body, err := c.HttpRequest(ctx, true, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, dynamicPipelinesProviderErrorAttributes)
 	}
 	retVal := &client.DynamicPipelinesProvider{}
 	err = json.NewDecoder(body).Decode(retVal)
@@ -149,7 +154,7 @@ func resourceDynamicPipelinesProviderDelete(ctx context.Context, d *schema.Resou
 	}
 	_, err = c.HttpRequest(ctx, true, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, dynamicPipelinesProviderErrorAttributes)
 	}
 	d.SetId("")
 	return diags
@@ -0,0 +1,63 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceDynamicPipelinesProvider(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_dynamic_pipelines_provider" "DynoProvider" {
+  repository_id = bitbucket_repository.Repo.id
+  provider_id   = "ari:cloud:ecosystem::app/first"
+}
+`,
+				Check: resource.TestCheckResourceAttr("bitbucket_dynamic_pipelines_provider.DynoProvider", "provider_id", "ari:cloud:ecosystem::app/first"),
+			},
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_dynamic_pipelines_provider" "DynoProvider" {
+  repository_id = bitbucket_repository.Repo.id
+  provider_id   = "ari:cloud:ecosystem::app/second"
+}
+`,
+				Check: resource.TestCheckResourceAttr("bitbucket_dynamic_pipelines_provider.DynoProvider", "provider_id", "ari:cloud:ecosystem::app/second"),
+			},
+			{
+				ResourceName:      "bitbucket_dynamic_pipelines_provider.DynoProvider",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceDynamicPipelinesProviderLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	d := schema.TestResourceDataRaw(t, resourceDynamicPipelinesProvider().Schema, map[string]interface{}{"repository_id": repository.Id(), "provider_id": "ari:first"})
+	checkDiags(t, resourceDynamicPipelinesProviderCreate(ctx, d, c))
+	d.Set("provider_id", "ari:second")
+	checkDiags(t, resourceDynamicPipelinesProviderUpdate(ctx, d, c))
+	checkDiags(t, resourceDynamicPipelinesProviderRead(ctx, d, c))
+	if d.Get("provider_id").(string) != "ari:second" {
+		t.Errorf("provider was not updated: %v", d.State())
+	}
+	checkDiags(t, resourceDynamicPipelinesProviderDelete(ctx, d, c))
+	if fb.singletons[repository.Id()+"/dynamic-pipelines-provider"]["appAri"] != "" {
+		t.Errorf("provider was not cleared on delete")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -21,8 +22,9 @@ func resourceEnvironment() *schema.Resource {
 		UpdateContext: resourceEnvironmentUpdate,
 		DeleteContext: resourceEnvironmentDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceEnvironmentImport,
 		},
+		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
 				Type:     schema.TypeString,
@@ -58,6 +60,56 @@ func resourceEnvironment() *schema.Resource {
 	}
 }
 
+func resourceEnvironmentImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	repository, environment, found := strings.Cut(d.Id(), "/")
+	if !found {
+		repositoryId, id, err := client.EnvironmentDecodeId(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("%w, or repository_slug/environment_name", err)
+		}
+		repositoryId, err = resolveRepositoryId(ctx, c, repositoryId)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(repositoryId + client.IdSeparator + id)
+		return []*schema.ResourceData{d}, nil
+	}
+	if (repository == "") || (environment == "") {
+		return nil, fmt.Errorf("invalid id %q: expected repository_slug/environment_name", d.Id())
+	}
+	repositoryId, err := resolveRepositoryId(ctx, c, repository)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(client.EnvironmentPath, c.Workspace, repositoryId)
+	retVals, err := client.HttpRequestAll[client.Environment](ctx, c, false, requestPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var retVal *client.Environment = nil
+	for _, e := range retVals {
+		if e.Name == environment {
+			if retVal != nil {
+				return nil, fmt.Errorf("more than one environment named %q found in repository %q, import it by uuid instead", environment, repository)
+			}
+			retVal = &e
+		}
+	}
+	if retVal == nil {
+		return nil, fmt.Errorf("no environment named %q found in repository %q", environment, repository)
+	}
+	retVal.RepositoryId = repositoryId
+	d.SetId(retVal.EnvironmentEncodeId())
+	return []*schema.ResourceData{d}, nil
+}
+
+var environmentErrorAttributes = map[string]string{
+	"name":             "name",
+	"environment_type": "type",
+	"restrictions":     "is_admin_only",
+}
+
 func fillEnvironment(c *client.Environment, d *schema.ResourceData) {
 	c.RepositoryId = d.Get("repository_id").(string)
 	c.Name = d.Get("name").(string)
@@ -83,20 +135,13 @@ func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m in
 	var retVal *client.Environment = nil
 	if newEnvironment.UseExisting {
 		// Try to find an existing environment with the given name and return it if found
-		// TODO: Paginate through results if many environments exist
 		requestPath := fmt.Sprintf(client.EnvironmentPath, c.Workspace, newEnvironment.RepositoryId)
-		body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(err)
-		}
-		retVals := &client.EnvironmentCollection{}
-		err = json.NewDecoder(body).Decode(retVals)
+		retVals, err := client.HttpRequestAll[client.Environment](ctx, c, false, requestPath, nil)
 		if err != nil {
 			d.SetId("")
 			return diag.FromErr(err)
 		}
-		for _, e := range retVals.Values {
+		for _, e := range retVals {
 			if e.Name == newEnvironment.Name {
 				retVal = &e
 				break
@@ -117,7 +162,7 @@ func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m in
 		body, err := c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 		if err != nil {
 			d.SetId("")
-			return diag.FromErr(err)
+			return diagFromRequestError(err, environmentErrorAttributes)
 		}
 		retVal = &client.Environment{}
 		err = json.NewDecoder(body).Decode(retVal)
@@ -135,7 +180,10 @@ func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m in
 func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.EnvironmentDecodeId(d.Id())
+	repositoryId, id, err := client.EnvironmentDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	requestPath := fmt.Sprintf(client.EnvironmentPathGet, c.Workspace, repositoryId, id)
 	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
@@ -160,12 +208,15 @@ func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, m inte
 func resourceEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.EnvironmentDecodeId(d.Id())
+	repositoryId, id, err := client.EnvironmentDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	buf := bytes.Buffer{}
 	upEnvironment := client.EnvironmentChanges{}
 	upEnvironment.Change.Name = d.Get("name").(string)
 	upEnvironment.Change.Restrictions.AdminOnly = d.Get("is_admin_only").(bool)
-	err := json.NewEncoder(&buf).Encode(upEnvironment)
+	err = json.NewEncoder(&buf).Encode(upEnvironment)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -175,7 +226,7 @@ func resourceEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	_, err = c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, environmentErrorAttributes)
 	}
 	// API does not return updated object, so we need to read it again
 	requestPath = fmt.Sprintf(client.EnvironmentPathGet, c.Workspace, repositoryId, id)
@@ -202,9 +253,12 @@ func resourceEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, m in
 func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.EnvironmentDecodeId(d.Id())
+	repositoryId, id, err := client.EnvironmentDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	requestPath := fmt.Sprintf(client.EnvironmentPathGet, c.Workspace, repositoryId, id)
-	_, err := c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
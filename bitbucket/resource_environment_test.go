@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testConfigEnvironment(name string) string {
+	return testConfigRepository + `
+resource "bitbucket_environment" "Env" {
+  repository_id = bitbucket_repository.Repo.id
+  name          = "` + name + `"
+  type          = "Staging"
+  is_admin_only = true
+}
+`
+}
+
+func TestResourceEnvironment(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigEnvironment("Stage"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_environment.Env", "uuid"),
+					resource.TestCheckResourceAttr("bitbucket_environment.Env", "name", "Stage"),
+					resource.TestCheckResourceAttr("bitbucket_environment.Env", "type", "Staging"),
+					resource.TestCheckResourceAttr("bitbucket_environment.Env", "is_admin_only", "true"),
+				),
+			},
+			{
+				Config: testConfigEnvironment("Pre-Production"),
+				Check:  resource.TestCheckResourceAttr("bitbucket_environment.Env", "name", "Pre-Production"),
+			},
+			{
+				ResourceName:            "bitbucket_environment.Env",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+			{
+				ResourceName:            "bitbucket_environment.Env",
+				ImportState:             true,
+				ImportStateId:           "test-repo/Pre-Production",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
+
+func TestResourceEnvironmentLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	//More environments than fit on a page, so use_existing has to paginate to find the last one
+	for _, name := range []string{"Test", "Staging", "Production"} {
+		d := schema.TestResourceDataRaw(t, resourceEnvironment().Schema, map[string]interface{}{"repository_id": repository.Id(), "name": name, "type": name})
+		checkDiags(t, resourceEnvironmentCreate(ctx, d, c))
+	}
+	d := schema.TestResourceDataRaw(t, resourceEnvironment().Schema, map[string]interface{}{"repository_id": repository.Id(), "name": "Production", "type": "Production", "use_existing": true})
+	checkDiags(t, resourceEnvironmentCreate(ctx, d, c))
+	if count := len(fb.collections[repository.Id()+"/environments"]); count != 3 {
+		t.Errorf("use_existing created a duplicate, %d environments exist", count)
+	}
+	d.Set("name", "Prod")
+	d.Set("is_admin_only", true)
+	checkDiags(t, resourceEnvironmentUpdate(ctx, d, c))
+	if (d.Get("name").(string) != "Prod") || !d.Get("is_admin_only").(bool) {
+		t.Errorf("environment was not updated: %v", d.State())
+	}
+	imported := schema.TestResourceDataRaw(t, resourceEnvironment().Schema, map[string]interface{}{})
+	imported.SetId("test-repo/Prod")
+	_, err := resourceEnvironmentImport(ctx, imported, c)
+	if (err != nil) || (imported.Id() != d.Id()) {
+		t.Errorf("friendly import resolved to %q, %v; expected %q", imported.Id(), err, d.Id())
+	}
+	imported.SetId("not-an-id")
+	_, err = resourceEnvironmentImport(ctx, imported, c)
+	if err == nil {
+		t.Errorf("malformed import id should be rejected")
+	}
+	id := d.Id()
+	checkDiags(t, resourceEnvironmentDelete(ctx, d, c))
+	d.SetId(id)
+	checkDiags(t, resourceEnvironmentRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("deleted environment should be removed from state")
+	}
+}
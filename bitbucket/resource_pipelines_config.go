@@ -22,6 +22,7 @@ func resourcePipelinesConfig() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
 				Type:     schema.TypeString,
@@ -37,6 +38,10 @@ func resourcePipelinesConfig() *schema.Resource {
 	}
 }
 
+var pipelinesConfigErrorAttributes = map[string]string{
+	"enabled": "is_enabled",
+}
+
 func fillPipelinesConfig(c *client.PipelinesConfig, d *schema.ResourceData) {
 	c.RepositoryId = d.Get("repository_id").(string)
 	c.Enabled = d.Get("is_enabled").(bool)
@@ -66,7 +71,7 @@ func resourcePipelinesConfigCreate(ctx context.Context, d *schema.ResourceData,
 	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(err)
+		return diagFromRequestError(err, pipelinesConfigErrorAttributes)
 	}
 	retVal := &client.PipelinesConfig{}
 	err = json.NewDecoder(body).Decode(retVal)
@@ -120,7 +125,7 @@ func resourcePipelinesConfigUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, pipelinesConfigErrorAttributes)
 	}
 	retVal := &client.PipelinesConfig{}
 	err = json.NewDecoder(body).Decode(retVal)
@@ -150,7 +155,7 @@ func resourcePipelinesConfigDelete(ctx context.Context, d *schema.ResourceData,
 	}
 	_, err = c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, pipelinesConfigErrorAttributes)
 	}
 	d.SetId("")
 	return diags
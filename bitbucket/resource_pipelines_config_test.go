@@ -0,0 +1,52 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourcePipelinesConfig(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_pipelines_config" "PipeConfig" {
+  repository_id = bitbucket_repository.Repo.id
+  is_enabled    = true
+}
+`,
+				Check: resource.TestCheckResourceAttr("bitbucket_pipelines_config.PipeConfig", "is_enabled", "true"),
+			},
+			{
+				ResourceName:      "bitbucket_pipelines_config.PipeConfig",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourcePipelinesConfigLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	d := schema.TestResourceDataRaw(t, resourcePipelinesConfig().Schema, map[string]interface{}{"repository_id": repository.Id(), "is_enabled": true})
+	checkDiags(t, resourcePipelinesConfigCreate(ctx, d, c))
+	checkDiags(t, resourcePipelinesConfigRead(ctx, d, c))
+	if !d.Get("is_enabled").(bool) {
+		t.Errorf("pipelines were not enabled")
+	}
+	checkDiags(t, resourcePipelinesConfigDelete(ctx, d, c))
+	if fb.singletons[repository.Id()+"/pipelines_config"]["enabled"] != false {
+		t.Errorf("pipelines were not disabled on delete")
+	}
+}
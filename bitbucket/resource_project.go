@@ -0,0 +1,251 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+func resourceProject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectImport,
+		},
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`), "must start with an uppercase letter and only contain uppercase letters, digits and underscores"),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"avatar": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^data:image/[a-z+.-]+;base64,`), "must be a base64 encoded image data URL"),
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"avatar_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_publicly_visible_repos": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+var projectErrorAttributes = map[string]string{
+	"key":         "key",
+	"name":        "name",
+	"description": "description",
+	"is_private":  "is_private",
+	"links":       "avatar",
+	"avatar":      "avatar",
+}
+
+// The id is the UUID, like the bitbucket_project data source and the project_id of a repository, but Bitbucket
+// addresses projects by key
+func resourceProjectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	key := ""
+	uuid := d.Id()
+	if !strings.HasPrefix(uuid, "{") {
+		key = uuid
+		uuid = ""
+	}
+	retVal, err := readProject(ctx, c, key, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if retVal == nil {
+		return nil, fmt.Errorf("no project %s in workspace %s", d.Id(), c.Workspace)
+	}
+	d.SetId(retVal.Uuid)
+	d.Set("key", retVal.Key)
+	return []*schema.ResourceData{d}, nil
+}
+
+// readProject finds a project by key, or by UUID when the key is unknown or now belongs to another project, and
+// returns nil when there is no such project
+func readProject(ctx context.Context, c *client.Client, key string, uuid string) (*client.Project, error) {
+	if key != "" {
+		requestPath := fmt.Sprintf(client.ProjectPath, c.Workspace, key)
+		body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+		if err != nil {
+			re := err.(*client.RequestError)
+			if re.StatusCode != http.StatusNotFound {
+				return nil, err
+			}
+		} else {
+			retVal := &client.Project{}
+			err = json.NewDecoder(body).Decode(retVal)
+			if err != nil {
+				return nil, err
+			}
+			if (uuid == "") || (retVal.Uuid == uuid) {
+				return retVal, nil
+			}
+		}
+	}
+	if uuid == "" {
+		return nil, nil
+	}
+	// The key was changed outside of Terraform
+	requestPath := fmt.Sprintf(client.ProjectsPath, c.Workspace)
+	requestQuery := url.Values{
+		client.QueryParam: []string{"uuid = " + bbqlString(uuid)},
+	}
+	retVals, err := client.HttpRequestAll[client.Project](ctx, c, false, requestPath, requestQuery)
+	if err != nil {
+		return nil, err
+	}
+	for _, retVal := range retVals {
+		if retVal.Uuid == uuid {
+			return &retVal, nil
+		}
+	}
+	return nil, nil
+}
+
+func fillProject(c *client.Project, d *schema.ResourceData) {
+	c.Key = d.Get("key").(string)
+	c.Name = d.Get("name").(string)
+	c.Description = d.Get("description").(string)
+	c.IsPrivate = d.Get("is_private").(bool)
+	// Bitbucket only accepts a new avatar as a data URL and always returns a link to where it is hosted
+	avatar, ok := d.GetOk("avatar")
+	if ok && d.HasChange("avatar") {
+		c.Links = &client.ProjectLinks{Avatar: &client.Link{Href: avatar.(string)}}
+	}
+}
+
+func fillResourceDataFromProject(c *client.Project, d *schema.ResourceData) {
+	d.Set("key", c.Key)
+	d.Set("name", c.Name)
+	d.Set("description", c.Description)
+	d.Set("is_private", c.IsPrivate)
+	d.Set("uuid", c.Uuid)
+	d.Set("has_publicly_visible_repos", c.HasPubliclyVisibleRepos)
+	d.Set("avatar_url", c.AvatarUrl())
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	buf := bytes.Buffer{}
+	newProject := client.Project{}
+	fillProject(&newProject, d)
+	err := json.NewEncoder(&buf).Encode(newProject)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.ProjectsPath, c.Workspace)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{client.ApplicationJson},
+	}
+	body, err := c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
+	if err != nil {
+		d.SetId("")
+		return diagFromRequestError(err, projectErrorAttributes)
+	}
+	retVal := &client.Project{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	fillResourceDataFromProject(retVal, d)
+	d.SetId(retVal.Uuid)
+	return diags
+}
+
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	retVal, err := readProject(ctx, c, d.Get("key").(string), d.Id())
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	if retVal == nil {
+		d.SetId("")
+		return diags
+	}
+	fillResourceDataFromProject(retVal, d)
+	return diags
+}
+
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	buf := bytes.Buffer{}
+	upProject := client.Project{}
+	fillProject(&upProject, d)
+	err := json.NewEncoder(&buf).Encode(upProject)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The project is addressed by its old key until the key change is done
+	oldKey, _ := d.GetChange("key")
+	requestPath := fmt.Sprintf(client.ProjectPath, c.Workspace, oldKey.(string))
+	requestHeaders := http.Header{
+		headers.ContentType: []string{client.ApplicationJson},
+	}
+	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	if err != nil {
+		return diagFromRequestError(err, projectErrorAttributes)
+	}
+	retVal := &client.Project{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fillResourceDataFromProject(retVal, d)
+	return diags
+}
+
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	requestPath := fmt.Sprintf(client.ProjectPath, c.Workspace, d.Get("key").(string))
+	_, err := c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,174 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+func resourceProjectGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectGroupPermissionCreate,
+		ReadContext:   resourceProjectGroupPermissionRead,
+		UpdateContext: resourceProjectGroupPermissionUpdate,
+		DeleteContext: resourceProjectGroupPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectGroupPermissionImport,
+		},
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectPermissionLevels, false),
+			},
+			"group_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceProjectGroupPermissionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_, _, err := client.ProjectGroupPermissionDecodeId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func fillProjectGroupPermission(c *client.ProjectGroupPermission, d *schema.ResourceData) {
+	c.ProjectKey = d.Get("project_key").(string)
+	c.GroupSlug = d.Get("group_slug").(string)
+	c.Permission = d.Get("permission").(string)
+}
+
+func fillResourceDataFromProjectGroupPermission(c *client.ProjectGroupPermission, d *schema.ResourceData) {
+	d.Set("project_key", c.ProjectKey)
+	d.Set("group_slug", c.GroupSlug)
+	d.Set("permission", c.Permission)
+	if c.Group != nil {
+		d.Set("group_name", c.Group.Name)
+	}
+}
+
+// putProjectGroupPermission grants a permission, which creates or replaces any permission the group already has
+func putProjectGroupPermission(ctx context.Context, c *client.Client, p *client.ProjectGroupPermission) (*client.ProjectGroupPermission, error) {
+	buf := bytes.Buffer{}
+	err := json.NewEncoder(&buf).Encode(p)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(client.ProjectGroupPermissionPathGet, c.Workspace, p.ProjectKey, p.GroupSlug)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{client.ApplicationJson},
+	}
+	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	if err != nil {
+		return nil, err
+	}
+	retVal := &client.ProjectGroupPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.ProjectKey = p.ProjectKey
+	retVal.GroupSlug = p.GroupSlug
+	return retVal, nil
+}
+
+func resourceProjectGroupPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newPermission := client.ProjectGroupPermission{}
+	fillProjectGroupPermission(&newPermission, d)
+	retVal, err := putProjectGroupPermission(ctx, c, &newPermission)
+	if err != nil {
+		d.SetId("")
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromProjectGroupPermission(retVal, d)
+	d.SetId(retVal.ProjectGroupPermissionEncodeId())
+	return diags
+}
+
+func resourceProjectGroupPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	projectKey, groupSlug, err := client.ProjectGroupPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.ProjectGroupPermissionPathGet, c.Workspace, projectKey, groupSlug)
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		d.SetId("")
+		re := err.(*client.RequestError)
+		if re.StatusCode == http.StatusNotFound {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	retVal := &client.ProjectGroupPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	retVal.ProjectKey = projectKey
+	retVal.GroupSlug = groupSlug
+	fillResourceDataFromProjectGroupPermission(retVal, d)
+	return diags
+}
+
+func resourceProjectGroupPermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	upPermission := client.ProjectGroupPermission{}
+	fillProjectGroupPermission(&upPermission, d)
+	retVal, err := putProjectGroupPermission(ctx, c, &upPermission)
+	if err != nil {
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromProjectGroupPermission(retVal, d)
+	return diags
+}
+
+func resourceProjectGroupPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	projectKey, groupSlug, err := client.ProjectGroupPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.ProjectGroupPermissionPathGet, c.Workspace, projectKey, groupSlug)
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		// Already gone, for instance when the key of the project was changed
+		re := err.(*client.RequestError)
+		if re.StatusCode != http.StatusNotFound {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return diags
+}
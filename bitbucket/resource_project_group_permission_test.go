@@ -0,0 +1,74 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceProjectGroupPermission(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "bitbucket_project" "Proj" {
+  key = "PROJ"
+}
+
+resource "bitbucket_project_group_permission" "Perm" {
+  project_key = data.bitbucket_project.Proj.key
+  group_slug  = "developers"
+  permission  = "write"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_group_permission.Perm", "id", "PROJ:developers"),
+					resource.TestCheckResourceAttr("bitbucket_project_group_permission.Perm", "group_name", "Developers"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project_group_permission.Perm",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceProjectGroupPermissionLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceProjectGroupPermission().Schema, map[string]interface{}{
+		"project_key": testProjectKey,
+		"group_slug":  "developers",
+		"permission":  "read",
+	})
+	checkDiags(t, resourceProjectGroupPermissionCreate(ctx, d, c))
+	key := testProjectUuid + "/permissions-config/groups"
+	if (d.Id() != testProjectKey+":developers") || (len(fb.collections[key]) != 1) {
+		t.Fatalf("unexpected permission: %s, %v", d.Id(), d.State())
+	}
+	fb.collections[key][0]["permission"] = "write"
+	checkDiags(t, resourceProjectGroupPermissionRead(ctx, d, c))
+	if d.Get("permission").(string) != "write" {
+		t.Errorf("drift was not detected, got %s", d.Get("permission"))
+	}
+	d.Set("permission", "admin")
+	checkDiags(t, resourceProjectGroupPermissionUpdate(ctx, d, c))
+	if fb.collections[key][0]["permission"] != "admin" {
+		t.Errorf("permission was not updated")
+	}
+	checkDiags(t, resourceProjectGroupPermissionDelete(ctx, d, c))
+	d.SetId(testProjectKey + ":developers")
+	checkDiags(t, resourceProjectGroupPermissionRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("revoked permission should be removed from state")
+	}
+}
@@ -0,0 +1,122 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const testAvatar = "data:image/png;base64,iVBORw0KGgo="
+
+func TestResourceProject(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bitbucket_project" "Proj" {
+  key         = "NEW"
+  name        = "New Project"
+  description = "Created by Terraform"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("bitbucket_project.Proj", "id", "bitbucket_project.Proj", "uuid"),
+					resource.TestCheckResourceAttr("bitbucket_project.Proj", "key", "NEW"),
+					resource.TestCheckResourceAttr("bitbucket_project.Proj", "is_private", "true"),
+				),
+			},
+			{
+				Config: `
+resource "bitbucket_project" "Proj" {
+  key         = "RENAMED"
+  name        = "Renamed Project"
+  description = "Created by Terraform"
+  is_private  = false
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project.Proj", "key", "RENAMED"),
+					resource.TestCheckResourceAttr("bitbucket_project.Proj", "is_private", "false"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project.Proj",
+				ImportState:       true,
+				ImportStateId:     "renamed",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceProjectLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"key":         "NEW",
+		"name":        "New Project",
+		"description": "Created by Terraform",
+		"avatar":      testAvatar,
+	})
+	checkDiags(t, resourceProjectCreate(ctx, d, c))
+	if (fb.projects["NEW"] == nil) || (d.Id() != fb.projects["NEW"]["uuid"]) {
+		t.Fatalf("project was not created with its UUID as id: %s", d.Id())
+	}
+	if (d.Get("uuid").(string) != d.Id()) || (d.Get("avatar_url").(string) == "") || (d.Get("avatar").(string) != testAvatar) {
+		t.Errorf("unexpected project: %v", d.State())
+	}
+	id := d.Id()
+	// Start from state, like Terraform does, so the old key is known
+	d = resourceProject().Data(d.State())
+	d.Set("key", "RENAMED")
+	d.Set("is_private", false)
+	checkDiags(t, resourceProjectUpdate(ctx, d, c))
+	if (d.Id() != id) || (fb.projects["NEW"] != nil) || (fb.projects["RENAMED"]["is_private"] != false) {
+		t.Errorf("key change was not applied: %v", d.State())
+	}
+	for _, importId := range []string{"renamed", id} {
+		imported := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+		imported.SetId(importId)
+		_, err := resourceProjectImport(ctx, imported, c)
+		if err != nil {
+			t.Fatalf("unable to import %s: %v", importId, err)
+		}
+		checkDiags(t, resourceProjectRead(ctx, imported, c))
+		if (imported.Id() != id) || (imported.Get("key").(string) != "RENAMED") || (imported.Get("description").(string) != "Created by Terraform") {
+			t.Errorf("unexpected import of %s: %v", importId, imported.State())
+		}
+	}
+	// A key changed outside of Terraform is found again by UUID
+	fb.projects["OUTSIDE"] = fb.projects["RENAMED"]
+	fb.projects["OUTSIDE"]["key"] = "OUTSIDE"
+	delete(fb.projects, "RENAMED")
+	checkDiags(t, resourceProjectRead(ctx, d, c))
+	if (d.Id() != id) || (d.Get("key").(string) != "OUTSIDE") {
+		t.Errorf("project was not found by UUID: %v", d.State())
+	}
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id": d.Id(),
+		"name":       "Test Repo",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	if !resourceProjectDelete(ctx, d, c).HasError() {
+		t.Errorf("a project with repositories should not be deleted")
+	}
+	checkDiags(t, resourceRepositoryDelete(ctx, repository, c))
+	checkDiags(t, resourceProjectDelete(ctx, d, c))
+	if fb.projects["OUTSIDE"] != nil {
+		t.Errorf("project was not deleted")
+	}
+	d.SetId(id)
+	checkDiags(t, resourceProjectRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("deleted project should be removed from state")
+	}
+}
@@ -0,0 +1,187 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+// Projects add a level that only allows creating repositories
+var projectPermissionLevels = []string{"read", "write", "create-repo", "admin"}
+
+func resourceProjectUserPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectUserPermissionCreate,
+		ReadContext:   resourceProjectUserPermissionRead,
+		UpdateContext: resourceProjectUserPermissionUpdate,
+		DeleteContext: resourceProjectUserPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectUserPermissionImport,
+		},
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectPermissionLevels, false),
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceProjectUserPermissionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_, _, err := client.ProjectUserPermissionDecodeId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func fillProjectUserPermission(c *client.ProjectUserPermission, d *schema.ResourceData) {
+	c.ProjectKey = d.Get("project_key").(string)
+	c.UserId = d.Get("user_id").(string)
+	c.Permission = d.Get("permission").(string)
+}
+
+func fillResourceDataFromProjectUserPermission(c *client.ProjectUserPermission, d *schema.ResourceData) {
+	d.Set("project_key", c.ProjectKey)
+	d.Set("user_id", c.UserId)
+	d.Set("permission", c.Permission)
+	if c.User != nil {
+		d.Set("account_id", c.User.AccountId)
+		d.Set("user_uuid", c.User.Uuid)
+		d.Set("display_name", c.User.DisplayName)
+	}
+}
+
+// putProjectUserPermission grants a permission, which creates or replaces any permission the user already has
+func putProjectUserPermission(ctx context.Context, c *client.Client, p *client.ProjectUserPermission) (*client.ProjectUserPermission, error) {
+	buf := bytes.Buffer{}
+	err := json.NewEncoder(&buf).Encode(p)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(client.ProjectUserPermissionPathGet, c.Workspace, p.ProjectKey, p.UserId)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{client.ApplicationJson},
+	}
+	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	if err != nil {
+		return nil, err
+	}
+	retVal := &client.ProjectUserPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.ProjectKey = p.ProjectKey
+	retVal.UserId = p.UserId
+	return retVal, nil
+}
+
+func resourceProjectUserPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newPermission := client.ProjectUserPermission{}
+	fillProjectUserPermission(&newPermission, d)
+	retVal, err := putProjectUserPermission(ctx, c, &newPermission)
+	if err != nil {
+		d.SetId("")
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromProjectUserPermission(retVal, d)
+	d.SetId(retVal.ProjectUserPermissionEncodeId())
+	return diags
+}
+
+func resourceProjectUserPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	projectKey, userId, err := client.ProjectUserPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.ProjectUserPermissionPathGet, c.Workspace, projectKey, userId)
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		d.SetId("")
+		re := err.(*client.RequestError)
+		if re.StatusCode == http.StatusNotFound {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	retVal := &client.ProjectUserPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	retVal.ProjectKey = projectKey
+	retVal.UserId = userId
+	fillResourceDataFromProjectUserPermission(retVal, d)
+	return diags
+}
+
+func resourceProjectUserPermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	upPermission := client.ProjectUserPermission{}
+	fillProjectUserPermission(&upPermission, d)
+	retVal, err := putProjectUserPermission(ctx, c, &upPermission)
+	if err != nil {
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromProjectUserPermission(retVal, d)
+	return diags
+}
+
+func resourceProjectUserPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	projectKey, userId, err := client.ProjectUserPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.ProjectUserPermissionPathGet, c.Workspace, projectKey, userId)
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		// Already gone, for instance when the key of the project was changed
+		re := err.(*client.RequestError)
+		if re.StatusCode != http.StatusNotFound {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceProjectUserPermission(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bitbucket_project_user_permission" "Perm" {
+  project_key = "PROJ"
+  user_id     = "{00000000-0000-0000-0000-00000000aaaa}"
+  permission  = "create-repo"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_project_user_permission.Perm", "permission", "create-repo"),
+					resource.TestCheckResourceAttr("bitbucket_project_user_permission.Perm", "account_id", testAccountId),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_project_user_permission.Perm",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceProjectUserPermissionLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceProjectUserPermission().Schema, map[string]interface{}{
+		"project_key": testProjectKey,
+		"user_id":     testAccountId,
+		"permission":  "write",
+	})
+	checkDiags(t, resourceProjectUserPermissionCreate(ctx, d, c))
+	key := testProjectUuid + "/permissions-config/users"
+	if (d.Id() != testProjectKey+":"+testAccountId) || (d.Get("display_name").(string) != "Alice") || (len(fb.collections[key]) != 1) {
+		t.Fatalf("unexpected permission: %s, %v", d.Id(), d.State())
+	}
+	fb.collections[key][0]["permission"] = "admin"
+	checkDiags(t, resourceProjectUserPermissionRead(ctx, d, c))
+	if d.Get("permission").(string) != "admin" {
+		t.Errorf("drift was not detected, got %s", d.Get("permission"))
+	}
+	d.Set("permission", "create-repo")
+	checkDiags(t, resourceProjectUserPermissionUpdate(ctx, d, c))
+	if fb.collections[key][0]["permission"] != "create-repo" {
+		t.Errorf("permission was not updated")
+	}
+	checkDiags(t, resourceProjectUserPermissionDelete(ctx, d, c))
+	if len(fb.collections[key]) != 0 {
+		t.Errorf("permission was not revoked")
+	}
+	// Revoking again, like after the project key changed, is not an error
+	d.SetId(testProjectKey + ":" + testAccountId)
+	checkDiags(t, resourceProjectUserPermissionDelete(ctx, d, c))
+}
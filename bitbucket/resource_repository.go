@@ -6,10 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
 )
 
@@ -20,8 +25,10 @@ func resourceRepository() *schema.Resource {
 		UpdateContext: resourceRepositoryUpdate,
 		DeleteContext: resourceRepositoryDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRepositoryImport,
 		},
+		CustomizeDiff: resourceRepositoryDiff,
+		Timeouts:      defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
@@ -36,20 +43,153 @@ func resourceRepository() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"language": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				// Bitbucket stores languages in lowercase
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool { return strings.EqualFold(old, new) },
+			},
+			"fork_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow_forks", "no_public_forks", "no_forks"}, false),
+			},
+			"has_issues": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"has_wiki": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"website": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"main_branch": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 			"use_existing": {
 				Type:             schema.TypeBool,
 				Optional:         true,
 				Default:          false,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool { return d.Id() != "" },
 			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onDestroyDelete,
+				ValidateFunc: validation.StringInSlice([]string{onDestroyDelete, onDestroyDetach}, false),
+			},
+			"slug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9._-]+$`), "must only contain lowercase letters, digits, dots, underscores and dashes"),
+			},
+			"full_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"clone_https": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"clone_ssh": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
+const (
+	onDestroyDelete = "delete"
+	onDestroyDetach = "detach"
+)
+
+var repositoryErrorAttributes = map[string]string{
+	"name":        "name",
+	"project":     "project_id",
+	"is_private":  "is_private",
+	"description": "description",
+	"language":    "language",
+	"fork_policy": "fork_policy",
+	"has_issues":  "has_issues",
+	"has_wiki":    "has_wiki",
+	"website":     "website",
+	"mainbranch":  "main_branch",
+	"slug":        "slug",
+}
+
+func resourceRepositoryImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Settings that only live in Terraform start at their defaults
+	d.Set("deletion_protection", false)
+	d.Set("on_destroy", onDestroyDelete)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRepositoryDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	// A slug that was derived from the name follows the name when it is renamed, a custom or configured slug is kept
+	rawConfig := d.GetRawConfig()
+	if d.HasChange("name") && !d.HasChange("slug") && (rawConfig.IsNull() || rawConfig.GetAttr("slug").IsNull()) {
+		oldName, _ := d.GetChange("name")
+		if d.Get("slug").(string) == convertNameToSlug(oldName.(string)) {
+			err := d.SetNewComputed("slug")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if d.HasChange("slug") {
+		for _, key := range []string{"full_name", "clone_https", "clone_ssh"} {
+			err := d.SetNewComputed(key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func fillRepository(c *client.Repository, d *schema.ResourceData) {
 	c.Project.Uuid = d.Get("project_id").(string)
 	c.Name = d.Get("name").(string)
 	c.IsPrivate = d.Get("is_private").(bool)
+	c.Description = d.Get("description").(string)
+	c.Language = d.Get("language").(string)
+	c.ForkPolicy = d.Get("fork_policy").(string)
+	c.HasIssues = d.Get("has_issues").(bool)
+	c.HasWiki = d.Get("has_wiki").(bool)
+	c.Website = d.Get("website").(string)
+	mainBranch, ok := d.GetOk("main_branch")
+	if ok {
+		c.MainBranch = &client.Branch{Type: "branch", Name: mainBranch.(string)}
+	}
 	c.UseExisting = d.Get("use_existing").(bool)
 }
 
@@ -57,7 +197,20 @@ func fillResourceDataFromRepository(c *client.Repository, d *schema.ResourceData
 	d.Set("project_id", c.Project.Uuid)
 	d.Set("name", c.Name)
 	d.Set("is_private", c.IsPrivate)
-	d.Set("use_existing", c.UseExisting)
+	d.Set("description", c.Description)
+	d.Set("language", c.Language)
+	d.Set("fork_policy", c.ForkPolicy)
+	d.Set("has_issues", c.HasIssues)
+	d.Set("has_wiki", c.HasWiki)
+	d.Set("website", c.Website)
+	if c.MainBranch != nil {
+		d.Set("main_branch", c.MainBranch.Name)
+	}
+	d.Set("slug", c.Slug)
+	d.Set("full_name", c.FullName)
+	d.Set("uuid", c.Uuid)
+	d.Set("clone_https", c.CloneUrl(client.CloneHttps))
+	d.Set("clone_ssh", c.CloneUrl(client.CloneSsh))
 }
 
 func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -65,8 +218,16 @@ func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m int
 	c := m.(*client.Client)
 	newRepository := client.Repository{}
 	fillRepository(&newRepository, d)
-	// Must convert name to slug
-	slug := convertNameToSlug(newRepository.Name)
+	// Must convert name to slug unless one is given
+	slug := d.Get("slug").(string)
+	if slug == "" {
+		var slugDiags diag.Diagnostics
+		slug, slugDiags = slugFromName(newRepository.Name, "name", "Set slug explicitly.")
+		if slugDiags.HasError() {
+			d.SetId("")
+			return slugDiags
+		}
+	}
 	var body *bytes.Buffer = nil
 	var err error
 	if newRepository.UseExisting {
@@ -95,7 +256,7 @@ func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m int
 		body, err = c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 		if err != nil {
 			d.SetId("")
-			return diag.FromErr(err)
+			return diagFromRequestError(err, repositoryErrorAttributes)
 		}
 	}
 	retVal := &client.Repository{}
@@ -109,6 +270,21 @@ func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m int
 	return diags
 }
 
+// verifyRepositoryProject warns when the repository is no longer in the project it was last seen in, so moves made
+// outside of Terraform are called out
+func verifyRepositoryProject(c *client.Repository, d *schema.ResourceData) diag.Diagnostics {
+	projectId := d.Get("project_id").(string)
+	if (projectId == "") || (projectId == c.Project.Uuid) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "Repository was moved",
+		Detail:        fmt.Sprintf("Repository %s is in project %s (%s) instead of %s", c.FullName, c.Project.Key, c.Project.Uuid, projectId),
+		AttributePath: cty.GetAttrPath("project_id"),
+	}}
+}
+
 func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
@@ -128,6 +304,7 @@ func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m inter
 		d.SetId("")
 		return diag.FromErr(err)
 	}
+	diags = append(diags, verifyRepositoryProject(retVal, d)...)
 	fillResourceDataFromRepository(retVal, d)
 	return diags
 }
@@ -138,6 +315,8 @@ func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m int
 	buf := bytes.Buffer{}
 	upRepository := client.Repository{}
 	fillRepository(&upRepository, d)
+	// An unknown slug is left for Bitbucket to derive from the new name
+	upRepository.Slug = d.Get("slug").(string)
 	err := json.NewEncoder(&buf).Encode(upRepository)
 	if err != nil {
 		return diag.FromErr(err)
@@ -148,13 +327,34 @@ func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m int
 	}
 	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, repositoryErrorAttributes)
 	}
 	retVal := &client.Repository{}
 	err = json.NewDecoder(body).Decode(retVal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	// Bitbucket ignores project changes it does not allow, so confirm the move actually happened
+	if d.HasChange("project_id") {
+		body, err = c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		retVal = &client.Repository{}
+		err = json.NewDecoder(body).Decode(retVal)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if retVal.Project.Uuid != upRepository.Project.Uuid {
+			d.Set("project_id", retVal.Project.Uuid)
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Repository was not moved",
+				Detail:        fmt.Sprintf("Bitbucket left repository %s in project %s instead of moving it to %s", retVal.FullName, retVal.Project.Uuid, upRepository.Project.Uuid),
+				AttributePath: cty.GetAttrPath("project_id"),
+			}}
+		}
+	}
 	fillResourceDataFromRepository(retVal, d)
 	return diags
 }
@@ -162,6 +362,20 @@ func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m int
 func resourceRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
+	// Detaching deletes nothing, so it is allowed even when protected
+	if d.Get("on_destroy").(string) == onDestroyDetach {
+		tflog.Info(ctx, "Detaching repository instead of deleting it", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return diags
+	}
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Repository is protected from deletion",
+			Detail:        fmt.Sprintf("Repository %s has deletion_protection enabled.  Set deletion_protection to false and apply before destroying it, or set on_destroy to %q to only remove it from the state.", d.Get("full_name").(string), onDestroyDetach),
+			AttributePath: cty.GetAttrPath("deletion_protection"),
+		}}
+	}
 	requestPath := fmt.Sprintf(client.RepositoryPath, c.Workspace, d.Id())
 	_, err := c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
@@ -0,0 +1,193 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+// A fork is managed like any other repository once it has been created from its parent
+func resourceRepositoryFork() *schema.Resource {
+	r := resourceRepository()
+	r.CreateContext = resourceRepositoryForkCreate
+	r.ReadContext = resourceRepositoryForkRead
+	r.Schema["parent_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		// The parent may be given by slug or UUID, so only a different parent replaces the fork
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			_, parentSlug, _ := strings.Cut(d.Get("parent_full_name").(string), "/")
+			return (old != "") && ((new == d.Get("parent_uuid").(string)) || (new == parentSlug))
+		},
+	}
+	r.Schema["parent_workspace"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+	r.Schema["parent_uuid"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	r.Schema["parent_full_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	return r
+}
+
+func fillResourceDataFromRepositoryParent(c *client.Repository, d *schema.ResourceData) {
+	if c.Parent == nil {
+		d.Set("parent_uuid", "")
+		d.Set("parent_full_name", "")
+		return
+	}
+	d.Set("parent_uuid", c.Parent.Uuid)
+	d.Set("parent_full_name", c.Parent.FullName)
+	// An import only knows the fork itself
+	if d.Get("parent_id").(string) == "" {
+		d.Set("parent_id", c.Parent.Uuid)
+	}
+	if d.Get("parent_workspace").(string) == "" {
+		d.Set("parent_workspace", strings.SplitN(c.Parent.FullName, "/", 2)[0])
+	}
+}
+
+func resourceRepositoryForkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newRepository := client.Repository{}
+	fillRepository(&newRepository, d)
+	newRepository.Slug = d.Get("slug").(string)
+	parentWorkspace := d.Get("parent_workspace").(string)
+	if parentWorkspace == "" {
+		parentWorkspace = c.Workspace
+	}
+	parentId := d.Get("parent_id").(string)
+	var body *bytes.Buffer = nil
+	var err error
+	if newRepository.UseExisting {
+		// Try to read an existing fork with the given slug and return it if found
+		slug := newRepository.Slug
+		if slug == "" {
+			var slugDiags diag.Diagnostics
+			slug, slugDiags = slugFromName(newRepository.Name, "name", "Set slug explicitly.")
+			if slugDiags.HasError() {
+				d.SetId("")
+				return slugDiags
+			}
+		}
+		requestPath := fmt.Sprintf(client.RepositoryPath, c.Workspace, slug)
+		body, err = c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+		if err != nil {
+			re := err.(*client.RequestError)
+			if re.StatusCode != http.StatusNotFound {
+				return diag.FromErr(err)
+			}
+			body = nil
+		}
+	}
+	retVal := &client.Repository{}
+	if body == nil {
+		// Forks inherit the main branch of their parent, so it is changed after forking
+		mainBranch := newRepository.MainBranch
+		newRepository.MainBranch = nil
+		newRepository.Workspace = &client.Workspace{Slug: c.Workspace}
+		buf := bytes.Buffer{}
+		err := json.NewEncoder(&buf).Encode(newRepository)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		requestPath := fmt.Sprintf(client.RepositoryForkPath, parentWorkspace, parentId)
+		requestHeaders := http.Header{
+			headers.ContentType: []string{client.ApplicationJson},
+		}
+		body, err = c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
+		if err != nil {
+			d.SetId("")
+			return diagFromRequestError(err, repositoryErrorAttributes)
+		}
+		err = json.NewDecoder(body).Decode(retVal)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		d.SetId(retVal.Uuid)
+		// An unconfigured website is whatever the fork ended up with
+		_, ok := d.GetOk("website")
+		if !ok {
+			newRepository.Website = retVal.Website
+		}
+		if ((mainBranch != nil) && ((retVal.MainBranch == nil) || (retVal.MainBranch.Name != mainBranch.Name))) || (retVal.Website != newRepository.Website) {
+			newRepository.MainBranch = mainBranch
+			newRepository.Workspace = nil
+			newRepository.Slug = retVal.Slug
+			buf := bytes.Buffer{}
+			err = json.NewEncoder(&buf).Encode(newRepository)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			requestPath = fmt.Sprintf(client.RepositoryPath, c.Workspace, retVal.Uuid)
+			body, err = c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
+			if err != nil {
+				return diagFromRequestError(err, repositoryErrorAttributes)
+			}
+			retVal = &client.Repository{}
+			err = json.NewDecoder(body).Decode(retVal)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		err = json.NewDecoder(body).Decode(retVal)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+		if retVal.Parent == nil {
+			d.SetId("")
+			return diag.Errorf("existing repository %s is not a fork", retVal.FullName)
+		}
+		d.SetId(retVal.Uuid)
+	}
+	d.Set("parent_workspace", parentWorkspace)
+	fillResourceDataFromRepository(retVal, d)
+	fillResourceDataFromRepositoryParent(retVal, d)
+	return diags
+}
+
+func resourceRepositoryForkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	requestPath := fmt.Sprintf(client.RepositoryPath, c.Workspace, d.Id())
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		d.SetId("")
+		re := err.(*client.RequestError)
+		if re.StatusCode == http.StatusNotFound {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	retVal := &client.Repository{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	diags = append(diags, verifyRepositoryProject(retVal, d)...)
+	fillResourceDataFromRepository(retVal, d)
+	fillResourceDataFromRepositoryParent(retVal, d)
+	return diags
+}
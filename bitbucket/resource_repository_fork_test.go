@@ -0,0 +1,124 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceRepositoryFork(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_repository_fork" "Fork" {
+  parent_id  = bitbucket_repository.Repo.id
+  project_id = data.bitbucket_project.Proj.id
+  name       = "Test Fork"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_repository_fork.Fork", "id"),
+					resource.TestCheckResourceAttr("bitbucket_repository_fork.Fork", "slug", "test-fork"),
+					resource.TestCheckResourceAttr("bitbucket_repository_fork.Fork", "parent_workspace", testWorkspace),
+					resource.TestCheckResourceAttrPair("bitbucket_repository_fork.Fork", "parent_uuid", "bitbucket_repository.Repo", "id"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_repository_fork.Fork",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
+
+func TestResourceRepositoryForkLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	parent := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":  testProjectUuid,
+		"name":        "Template",
+		"description": "Shared template",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, parent, c))
+	d := schema.TestResourceDataRaw(t, resourceRepositoryFork().Schema, map[string]interface{}{
+		"parent_id":   "template",
+		"project_id":  testOtherProjectUuid,
+		"name":        "Team Template",
+		"description": "Our fork",
+		"main_branch": "develop",
+		"website":     "https://example.com",
+	})
+	checkDiags(t, resourceRepositoryForkCreate(ctx, d, c))
+	fork := fb.repository("team-template")
+	if (fork == nil) || (d.Id() != fork["uuid"]) {
+		t.Fatalf("fork was not created")
+	}
+	expected := map[string]interface{}{
+		"parent_uuid":      parent.Id(),
+		"parent_full_name": testWorkspace + "/template",
+		"parent_workspace": testWorkspace,
+		"project_id":       testOtherProjectUuid,
+		"description":      "Our fork",
+		"main_branch":      "develop",
+		"website":          "https://example.com",
+	}
+	for key, value := range expected {
+		if d.Get(key) != value {
+			t.Errorf("%s = %v; expected %v", key, d.Get(key), value)
+		}
+	}
+	d.Set("has_wiki", true)
+	checkDiags(t, resourceRepositoryUpdate(ctx, d, c))
+	if fb.repository("team-template")["has_wiki"] != true {
+		t.Errorf("fork was not updated")
+	}
+	existing := schema.TestResourceDataRaw(t, resourceRepositoryFork().Schema, map[string]interface{}{
+		"parent_id":    "template",
+		"project_id":   testProjectUuid,
+		"name":         "Team Template",
+		"use_existing": true,
+	})
+	checkDiags(t, resourceRepositoryForkCreate(ctx, existing, c))
+	if existing.Id() != d.Id() {
+		t.Errorf("use_existing did not adopt %s, got %s", d.Id(), existing.Id())
+	}
+	imported := schema.TestResourceDataRaw(t, resourceRepositoryFork().Schema, map[string]interface{}{})
+	imported.SetId(d.Id())
+	checkDiags(t, resourceRepositoryForkRead(ctx, imported, c))
+	if (imported.Get("parent_id").(string) != parent.Id()) || (imported.Get("parent_workspace").(string) != testWorkspace) {
+		t.Errorf("import did not find the parent: %v", imported.State())
+	}
+	for _, parentId := range []string{"template", parent.Id()} {
+		config := map[string]interface{}{
+			"parent_id":   parentId,
+			"project_id":  testOtherProjectUuid,
+			"name":        "Team Template",
+			"description": "Our fork",
+			"main_branch": "develop",
+			"website":     "https://example.com",
+			"has_wiki":    true,
+		}
+		diff := testPlan(t, resourceRepositoryFork(), imported, config, c)
+		if diff.RequiresNew() {
+			t.Errorf("importing a fork with parent_id %s should not replace it: %v", parentId, diff)
+		}
+	}
+	config := map[string]interface{}{"parent_id": "other-template", "project_id": testOtherProjectUuid, "name": "Team Template"}
+	if !testPlan(t, resourceRepositoryFork(), imported, config, c).RequiresNew() {
+		t.Errorf("a different parent should replace the fork")
+	}
+	checkDiags(t, resourceRepositoryDelete(ctx, d, c))
+	if fb.repository("team-template") != nil {
+		t.Errorf("fork was not deleted")
+	}
+}
@@ -0,0 +1,176 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+func resourceRepositoryGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryGroupPermissionCreate,
+		ReadContext:   resourceRepositoryGroupPermissionRead,
+		UpdateContext: resourceRepositoryGroupPermissionUpdate,
+		DeleteContext: resourceRepositoryGroupPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRepositoryGroupPermissionImport,
+		},
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_slug": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(permissionLevels, false),
+			},
+			"group_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRepositoryGroupPermissionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	repositoryId, groupSlug, err := client.RepositoryGroupPermissionDecodeId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	repositoryId, err = resolveRepositoryId(ctx, c, repositoryId)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(repositoryId + client.IdSeparator + groupSlug)
+	return []*schema.ResourceData{d}, nil
+}
+
+func fillRepositoryGroupPermission(c *client.RepositoryGroupPermission, d *schema.ResourceData) {
+	c.RepositoryId = d.Get("repository_id").(string)
+	c.GroupSlug = d.Get("group_slug").(string)
+	c.Permission = d.Get("permission").(string)
+}
+
+func fillResourceDataFromRepositoryGroupPermission(c *client.RepositoryGroupPermission, d *schema.ResourceData) {
+	d.Set("repository_id", c.RepositoryId)
+	d.Set("group_slug", c.GroupSlug)
+	d.Set("permission", c.Permission)
+	if c.Group != nil {
+		d.Set("group_name", c.Group.Name)
+	}
+}
+
+// putRepositoryGroupPermission grants a permission, which creates or replaces any permission the group already has
+func putRepositoryGroupPermission(ctx context.Context, c *client.Client, p *client.RepositoryGroupPermission) (*client.RepositoryGroupPermission, error) {
+	buf := bytes.Buffer{}
+	err := json.NewEncoder(&buf).Encode(p)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(client.RepositoryGroupPermissionPathGet, c.Workspace, p.RepositoryId, p.GroupSlug)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{client.ApplicationJson},
+	}
+	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	if err != nil {
+		return nil, err
+	}
+	retVal := &client.RepositoryGroupPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.RepositoryId = p.RepositoryId
+	retVal.GroupSlug = p.GroupSlug
+	return retVal, nil
+}
+
+func resourceRepositoryGroupPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newPermission := client.RepositoryGroupPermission{}
+	fillRepositoryGroupPermission(&newPermission, d)
+	retVal, err := putRepositoryGroupPermission(ctx, c, &newPermission)
+	if err != nil {
+		d.SetId("")
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromRepositoryGroupPermission(retVal, d)
+	d.SetId(retVal.RepositoryGroupPermissionEncodeId())
+	return diags
+}
+
+func resourceRepositoryGroupPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	repositoryId, groupSlug, err := client.RepositoryGroupPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.RepositoryGroupPermissionPathGet, c.Workspace, repositoryId, groupSlug)
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		d.SetId("")
+		re := err.(*client.RequestError)
+		if re.StatusCode == http.StatusNotFound {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	retVal := &client.RepositoryGroupPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	retVal.RepositoryId = repositoryId
+	retVal.GroupSlug = groupSlug
+	fillResourceDataFromRepositoryGroupPermission(retVal, d)
+	return diags
+}
+
+func resourceRepositoryGroupPermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	upPermission := client.RepositoryGroupPermission{}
+	fillRepositoryGroupPermission(&upPermission, d)
+	retVal, err := putRepositoryGroupPermission(ctx, c, &upPermission)
+	if err != nil {
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromRepositoryGroupPermission(retVal, d)
+	return diags
+}
+
+func resourceRepositoryGroupPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	repositoryId, groupSlug, err := client.RepositoryGroupPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.RepositoryGroupPermissionPathGet, c.Workspace, repositoryId, groupSlug)
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
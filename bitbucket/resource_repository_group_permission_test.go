@@ -0,0 +1,78 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceRepositoryGroupPermission(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_repository_group_permission" "Perm" {
+  repository_id = bitbucket_repository.Repo.id
+  group_slug    = "developers"
+  permission    = "write"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_group_permission.Perm", "permission", "write"),
+					resource.TestCheckResourceAttr("bitbucket_repository_group_permission.Perm", "group_name", "Developers"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_repository_group_permission.Perm",
+				ImportState:       true,
+				ImportStateId:     "test-repo:developers",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceRepositoryGroupPermissionLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	r := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	d := schema.TestResourceDataRaw(t, resourceRepositoryGroupPermission().Schema, map[string]interface{}{
+		"repository_id": r.Id(),
+		"group_slug":    "developers",
+		"permission":    "write",
+	})
+	checkDiags(t, resourceRepositoryGroupPermissionCreate(ctx, d, c))
+	if (d.Id() != r.Id()+":developers") || (d.Get("group_name").(string) != "Developers") {
+		t.Fatalf("unexpected permission: %s, %v", d.Id(), d.State())
+	}
+	fb.collections[r.Id()+"/permissions-config/groups"][0]["permission"] = "read"
+	checkDiags(t, resourceRepositoryGroupPermissionRead(ctx, d, c))
+	if d.Get("permission").(string) != "read" {
+		t.Errorf("drift was not detected, got %s", d.Get("permission"))
+	}
+	d.Set("permission", "admin")
+	checkDiags(t, resourceRepositoryGroupPermissionUpdate(ctx, d, c))
+	if fb.collections[r.Id()+"/permissions-config/groups"][0]["permission"] != "admin" {
+		t.Errorf("permission was not updated")
+	}
+	missing := schema.TestResourceDataRaw(t, resourceRepositoryGroupPermission().Schema, map[string]interface{}{
+		"repository_id": r.Id(),
+		"group_slug":    "missing",
+		"permission":    "write",
+	})
+	if !resourceRepositoryGroupPermissionCreate(ctx, missing, c).HasError() {
+		t.Errorf("expected granting an unknown group to fail")
+	}
+	checkDiags(t, resourceRepositoryGroupPermissionDelete(ctx, d, c))
+	if len(fb.collections[r.Id()+"/permissions-config/groups"]) != 0 {
+		t.Errorf("permission was not revoked")
+	}
+}
@@ -0,0 +1,301 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+// bitbucket_repository_permissions owns every explicit user and group permission of a repository, so anything granted
+// outside of it is revoked
+func resourceRepositoryPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryPermissionsCreate,
+		ReadContext:   resourceRepositoryPermissionsRead,
+		UpdateContext: resourceRepositoryPermissionsUpdate,
+		DeleteContext: resourceRepositoryPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRepositoryPermissionsImport,
+		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: resourceRepositoryPermissionsDiff,
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(permissionLevels, false),
+						},
+					},
+				},
+			},
+			"group": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_slug": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(permissionLevels, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceRepositoryPermissionsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	repositoryId, err := resolveRepositoryId(ctx, c, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(repositoryId)
+	return []*schema.ResourceData{d}, nil
+}
+
+// Each user and group may only be declared once, since only one permission can be granted to it
+func resourceRepositoryPermissionsDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("user") || !d.NewValueKnown("group") {
+		return nil
+	}
+	groupSlugs := map[string]bool{}
+	for _, item := range d.Get("group").(*schema.Set).List() {
+		groupSlug := item.(map[string]interface{})["group_slug"].(string)
+		if groupSlugs[groupSlug] {
+			return fmt.Errorf("group %s is declared more than once", groupSlug)
+		}
+		groupSlugs[groupSlug] = true
+	}
+	userIds := map[string]bool{}
+	uuids := []string{}
+	for _, item := range d.Get("user").(*schema.Set).List() {
+		userId := item.(map[string]interface{})["user_id"].(string)
+		if userIds[userId] {
+			return fmt.Errorf("user %s is declared more than once", userId)
+		}
+		userIds[userId] = true
+		if strings.HasPrefix(userId, "{") {
+			uuids = append(uuids, userId)
+		}
+	}
+	// Only a mix of UUIDs and account ids can name the same user twice, so only then look the UUIDs up
+	if (len(uuids) == 0) || (len(uuids) == len(userIds)) {
+		return nil
+	}
+	c := m.(*client.Client)
+	for _, uuid := range uuids {
+		requestPath := fmt.Sprintf(client.UserPath, uuid)
+		body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+		if err != nil {
+			re, ok := err.(*client.RequestError)
+			if ok && (re.StatusCode == http.StatusNotFound) {
+				continue
+			}
+			return err
+		}
+		user := &client.Account{}
+		err = json.NewDecoder(body).Decode(user)
+		if err != nil {
+			return err
+		}
+		if userIds[user.AccountId] {
+			return fmt.Errorf("user %s is declared more than once, by account id %s and by UUID %s", user.DisplayName, user.AccountId, uuid)
+		}
+	}
+	return nil
+}
+
+// declaredPermissions maps each user_id or group_slug of a user or group set to its permission
+func declaredPermissions(set *schema.Set, idKey string) map[string]string {
+	retVal := map[string]string{}
+	for _, item := range set.List() {
+		permission := item.(map[string]interface{})
+		retVal[permission[idKey].(string)] = permission["permission"].(string)
+	}
+	return retVal
+}
+
+// declaredUserId finds how a user is declared, since it may be by account id or UUID
+func declaredUserId(user *client.Account, declared map[string]string) (string, bool) {
+	for _, id := range []string{user.AccountId, user.Uuid} {
+		_, ok := declared[id]
+		if ok && (id != "") {
+			return id, true
+		}
+	}
+	return user.AccountId, false
+}
+
+func readRepositoryPermissions(ctx context.Context, c *client.Client, repositoryId string) ([]client.RepositoryUserPermission, []client.RepositoryGroupPermission, error) {
+	requestPath := fmt.Sprintf(client.RepositoryUserPermissionPath, c.Workspace, repositoryId)
+	users, err := client.HttpRequestAll[client.RepositoryUserPermission](ctx, c, false, requestPath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	requestPath = fmt.Sprintf(client.RepositoryGroupPermissionPath, c.Workspace, repositoryId)
+	groups, err := client.HttpRequestAll[client.RepositoryGroupPermission](ctx, c, false, requestPath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, groups, nil
+}
+
+// applyRepositoryPermissions grants every declared permission that differs from the live one and revokes the rest
+func applyRepositoryPermissions(ctx context.Context, c *client.Client, repositoryId string, users map[string]string, groups map[string]string) error {
+	liveUsers, liveGroups, err := readRepositoryPermissions(ctx, c, repositoryId)
+	if err != nil {
+		return err
+	}
+	grantedUsers := map[string]string{}
+	for _, live := range liveUsers {
+		if live.User == nil {
+			continue
+		}
+		userId, declared := declaredUserId(live.User, users)
+		if !declared {
+			tflog.Info(ctx, "Revoking undeclared repository permission", map[string]interface{}{"repository_id": repositoryId, "user_id": userId})
+			requestPath := fmt.Sprintf(client.RepositoryUserPermissionPathGet, c.Workspace, repositoryId, live.User.Uuid)
+			_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		grantedUsers[userId] = live.Permission
+	}
+	for userId, permission := range users {
+		if grantedUsers[userId] == permission {
+			continue
+		}
+		_, err = putRepositoryUserPermission(ctx, c, &client.RepositoryUserPermission{RepositoryId: repositoryId, UserId: userId, Permission: permission})
+		if err != nil {
+			return err
+		}
+	}
+	grantedGroups := map[string]string{}
+	for _, live := range liveGroups {
+		if live.Group == nil {
+			continue
+		}
+		_, declared := groups[live.Group.Slug]
+		if !declared {
+			tflog.Info(ctx, "Revoking undeclared repository permission", map[string]interface{}{"repository_id": repositoryId, "group_slug": live.Group.Slug})
+			requestPath := fmt.Sprintf(client.RepositoryGroupPermissionPathGet, c.Workspace, repositoryId, live.Group.Slug)
+			_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		grantedGroups[live.Group.Slug] = live.Permission
+	}
+	for groupSlug, permission := range groups {
+		if grantedGroups[groupSlug] == permission {
+			continue
+		}
+		_, err = putRepositoryGroupPermission(ctx, c, &client.RepositoryGroupPermission{RepositoryId: repositoryId, GroupSlug: groupSlug, Permission: permission})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceRepositoryPermissionsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	repositoryId := d.Get("repository_id").(string)
+	users := declaredPermissions(d.Get("user").(*schema.Set), "user_id")
+	groups := declaredPermissions(d.Get("group").(*schema.Set), "group_slug")
+	err := applyRepositoryPermissions(ctx, c, repositoryId, users, groups)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	d.SetId(repositoryId)
+	return resourceRepositoryPermissionsRead(ctx, d, m)
+}
+
+func resourceRepositoryPermissionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	liveUsers, liveGroups, err := readRepositoryPermissions(ctx, c, d.Id())
+	if err != nil {
+		d.SetId("")
+		re, ok := err.(*client.RequestError)
+		if ok && (re.StatusCode == http.StatusNotFound) {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	declaredUsers := declaredPermissions(d.Get("user").(*schema.Set), "user_id")
+	users := []map[string]interface{}{}
+	for _, live := range liveUsers {
+		if live.User == nil {
+			continue
+		}
+		userId, _ := declaredUserId(live.User, declaredUsers)
+		users = append(users, map[string]interface{}{"user_id": userId, "permission": live.Permission})
+	}
+	groups := []map[string]interface{}{}
+	for _, live := range liveGroups {
+		if live.Group == nil {
+			continue
+		}
+		groups = append(groups, map[string]interface{}{"group_slug": live.Group.Slug, "permission": live.Permission})
+	}
+	d.Set("repository_id", d.Id())
+	d.Set("user", users)
+	d.Set("group", groups)
+	return diags
+}
+
+func resourceRepositoryPermissionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	users := declaredPermissions(d.Get("user").(*schema.Set), "user_id")
+	groups := declaredPermissions(d.Get("group").(*schema.Set), "group_slug")
+	err := applyRepositoryPermissions(ctx, c, d.Id(), users, groups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRepositoryPermissionsRead(ctx, d, m)
+}
+
+func resourceRepositoryPermissionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	err := applyRepositoryPermissions(ctx, c, d.Id(), map[string]string{}, map[string]string{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
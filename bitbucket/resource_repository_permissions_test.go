@@ -0,0 +1,152 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceRepositoryPermissions(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_repository_permissions" "Perms" {
+  repository_id = bitbucket_repository.Repo.id
+  user {
+    user_id    = "557058:00000000-0000-0000-0000-00000000aaaa"
+    permission = "write"
+  }
+  group {
+    group_slug = "developers"
+    permission = "read"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_permissions.Perms", "user.#", "1"),
+					resource.TestCheckResourceAttr("bitbucket_repository_permissions.Perms", "group.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_repository_permissions.Perms",
+				ImportState:       true,
+				ImportStateId:     "test-repo",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceRepositoryPermissionsLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	r := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	// Grants made in the UI before Terraform takes over
+	stray := schema.TestResourceDataRaw(t, resourceRepositoryGroupPermission().Schema, map[string]interface{}{"repository_id": r.Id(), "group_slug": "administrators", "permission": "admin"})
+	checkDiags(t, resourceRepositoryGroupPermissionCreate(ctx, stray, c))
+	existing := schema.TestResourceDataRaw(t, resourceRepositoryUserPermission().Schema, map[string]interface{}{"repository_id": r.Id(), "user_id": testAccountId, "permission": "read"})
+	checkDiags(t, resourceRepositoryUserPermissionCreate(ctx, existing, c))
+	d := schema.TestResourceDataRaw(t, resourceRepositoryPermissions().Schema, map[string]interface{}{
+		"repository_id": r.Id(),
+		"user": []interface{}{
+			map[string]interface{}{"user_id": testUserUuid, "permission": "write"},
+		},
+		"group": []interface{}{
+			map[string]interface{}{"group_slug": "developers", "permission": "read"},
+		},
+	})
+	checkDiags(t, resourceRepositoryPermissionsCreate(ctx, d, c))
+	users := fb.collections[r.Id()+"/permissions-config/users"]
+	groups := fb.collections[r.Id()+"/permissions-config/groups"]
+	if (len(users) != 1) || (users[0]["permission"] != "write") {
+		t.Errorf("unexpected user permissions: %v", users)
+	}
+	if (len(groups) != 1) || (groups[0]["group"].(map[string]any)["slug"] != "developers") {
+		t.Errorf("stray group permission was not revoked: %v", groups)
+	}
+	expected := schema.NewSet(d.Get("user").(*schema.Set).F, []interface{}{map[string]interface{}{"user_id": testUserUuid, "permission": "write"}})
+	if !d.Get("user").(*schema.Set).Equal(expected) {
+		t.Errorf("user should keep the declared id: %v", d.Get("user"))
+	}
+	// Drift in the UI shows up on the next read
+	stray = schema.TestResourceDataRaw(t, resourceRepositoryUserPermission().Schema, map[string]interface{}{"repository_id": r.Id(), "user_id": "5b10a2844c20165700ede21g", "permission": "admin"})
+	checkDiags(t, resourceRepositoryUserPermissionCreate(ctx, stray, c))
+	checkDiags(t, resourceRepositoryPermissionsRead(ctx, d, c))
+	if d.Get("user").(*schema.Set).Len() != 2 {
+		t.Errorf("stray user permission was not read: %v", d.Get("user"))
+	}
+	d.Set("user", []interface{}{map[string]interface{}{"user_id": testUserUuid, "permission": "admin"}})
+	d.Set("group", []interface{}{})
+	checkDiags(t, resourceRepositoryPermissionsUpdate(ctx, d, c))
+	users = fb.collections[r.Id()+"/permissions-config/users"]
+	if (len(users) != 1) || (users[0]["permission"] != "admin") || (len(fb.collections[r.Id()+"/permissions-config/groups"]) != 0) {
+		t.Errorf("update did not converge: %v, %v", users, fb.collections[r.Id()+"/permissions-config/groups"])
+	}
+	checkDiags(t, resourceRepositoryPermissionsDelete(ctx, d, c))
+	if len(fb.collections[r.Id()+"/permissions-config/users"]) != 0 {
+		t.Errorf("permissions were not revoked")
+	}
+}
+
+func TestResourceRepositoryPermissionsDuplicates(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	r := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	duplicates := map[string][]interface{}{
+		"same user_id": {
+			map[string]interface{}{"user_id": testAccountId, "permission": "read"},
+			map[string]interface{}{"user_id": testAccountId, "permission": "admin"},
+		},
+		"account id and UUID": {
+			map[string]interface{}{"user_id": testAccountId, "permission": "read"},
+			map[string]interface{}{"user_id": testUserUuid, "permission": "admin"},
+		},
+	}
+	for name, users := range duplicates {
+		config := map[string]interface{}{"repository_id": r.Id(), "user": users}
+		_, err := resourceRepositoryPermissions().Diff(ctx, nil, terraform.NewResourceConfigRaw(config), c)
+		if err == nil {
+			t.Errorf("%s: expected duplicate users to be rejected", name)
+		}
+	}
+	config := map[string]interface{}{
+		"repository_id": r.Id(),
+		"group": []interface{}{
+			map[string]interface{}{"group_slug": "developers", "permission": "read"},
+			map[string]interface{}{"group_slug": "developers", "permission": "write"},
+		},
+	}
+	_, err := resourceRepositoryPermissions().Diff(ctx, nil, terraform.NewResourceConfigRaw(config), c)
+	if err == nil {
+		t.Errorf("expected duplicate groups to be rejected")
+	}
+	// Different users, mixing both forms, are fine and can be applied
+	config = map[string]interface{}{
+		"repository_id": r.Id(),
+		"user": []interface{}{
+			map[string]interface{}{"user_id": testAccountId, "permission": "read"},
+			map[string]interface{}{"user_id": fakeUsers[1]["uuid"], "permission": "admin"},
+		},
+	}
+	_, err = resourceRepositoryPermissions().Diff(ctx, nil, terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := schema.TestResourceDataRaw(t, resourceRepositoryPermissions().Schema, config)
+	checkDiags(t, resourceRepositoryPermissionsCreate(ctx, d, c))
+	if d.Get("user").(*schema.Set).Len() != 2 {
+		t.Errorf("both users should be granted: %v", d.Get("user").(*schema.Set).List())
+	}
+}
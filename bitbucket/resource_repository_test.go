@@ -0,0 +1,314 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceRepository(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_repository.Repo", "id"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "name", "Test Repo"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "project_id", testProjectUuid),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "is_private", "true"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "slug", "test-repo"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "fork_policy", "allow_forks"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "main_branch", "main"),
+					resource.TestCheckResourceAttrSet("bitbucket_repository.Repo", "clone_ssh"),
+				),
+			},
+			{
+				Config: `
+data "bitbucket_project" "Proj" {
+  key = "PROJ"
+}
+
+resource "bitbucket_repository" "Repo" {
+  project_id = data.bitbucket_project.Proj.id
+  name       = "Test Repo"
+  is_private = false
+}
+`,
+				Check: resource.TestCheckResourceAttr("bitbucket_repository.Repo", "is_private", "false"),
+			},
+			{
+				Config: `
+data "bitbucket_project" "Proj" {
+  key = "PROJ"
+}
+
+resource "bitbucket_repository" "Repo" {
+  project_id = data.bitbucket_project.Proj.id
+  name       = "Renamed Repo"
+  is_private = false
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "slug", "renamed-repo"),
+					resource.TestCheckResourceAttr("bitbucket_repository.Repo", "full_name", testWorkspace+"/renamed-repo"),
+				),
+			},
+			{
+				ResourceName:            "bitbucket_repository.Repo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
+
+func TestResourceRepositoryLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":  testProjectUuid,
+		"name":        "Test Repo",
+		"description": "A test repository",
+		"language":    "Go",
+		"fork_policy": "no_public_forks",
+		"has_issues":  true,
+		"main_branch": "develop",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, d, c))
+	if (d.Id() == "") || (fb.repository("test-repo") == nil) {
+		t.Fatalf("repository was not created")
+	}
+	checkDiags(t, resourceRepositoryRead(ctx, d, c))
+	if (d.Get("name").(string) != "Test Repo") || !d.Get("is_private").(bool) {
+		t.Errorf("unexpected repository: %v", d.State())
+	}
+	expected := map[string]interface{}{
+		"description": "A test repository",
+		"language":    "go",
+		"fork_policy": "no_public_forks",
+		"has_issues":  true,
+		"has_wiki":    false,
+		"website":     "",
+		"main_branch": "develop",
+		"slug":        "test-repo",
+		"full_name":   testWorkspace + "/test-repo",
+		"uuid":        d.Id(),
+		"clone_https": "https://bitbucket.org/" + testWorkspace + "/test-repo.git",
+		"clone_ssh":   "git@bitbucket.org:" + testWorkspace + "/test-repo.git",
+	}
+	for key, value := range expected {
+		if d.Get(key) != value {
+			t.Errorf("%s = %v; expected %v", key, d.Get(key), value)
+		}
+	}
+	d.Set("is_private", false)
+	d.Set("fork_policy", "allow_forks")
+	d.Set("website", "https://example.com")
+	checkDiags(t, resourceRepositoryUpdate(ctx, d, c))
+	updated := fb.repository("test-repo")
+	if (updated["is_private"] != false) || (updated["fork_policy"] != "allow_forks") || (updated["website"] != "https://example.com") {
+		t.Errorf("repository was not updated: %v", updated)
+	}
+	existing := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":   testProjectUuid,
+		"name":         "Test Repo",
+		"use_existing": true,
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, existing, c))
+	if existing.Id() != d.Id() {
+		t.Errorf("use_existing did not adopt %s, got %s", d.Id(), existing.Id())
+	}
+	checkDiags(t, resourceRepositoryDelete(ctx, d, c))
+	if fb.repository("test-repo") != nil {
+		t.Errorf("repository was not deleted")
+	}
+	d.SetId(existing.Id())
+	checkDiags(t, resourceRepositoryRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("deleted repository should be removed from state")
+	}
+}
+
+func TestResourceRepositoryUnconfiguredSettings(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	configured := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":  testProjectUuid,
+		"name":        "Test Repo",
+		"description": "Set outside of Terraform",
+		"has_issues":  true,
+		"has_wiki":    true,
+		"website":     "https://example.com",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, configured, c))
+	// State written before these settings existed only knows the id
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{})
+	d.SetId(configured.Id())
+	checkDiags(t, resourceRepositoryRead(ctx, d, c))
+	diff := testPlan(t, resourceRepository(), d, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"}, c)
+	for _, key := range []string{"description", "has_issues", "has_wiki", "website"} {
+		if diff.Attributes[key] != nil {
+			t.Errorf("unconfigured %s should be left as is: %v", key, diff.Attributes[key])
+		}
+	}
+}
+
+func TestResourceRepositoryRename(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id": testProjectUuid,
+		"name":       "Crème Brûlée!",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, d, c))
+	if (d.Get("slug").(string) != "creme-brulee") || (fb.repository("creme-brulee") == nil) {
+		t.Fatalf("repository was not created with a Bitbucket slug, got %q", d.Get("slug"))
+	}
+	id := d.Id()
+	d.Set("name", "Renamed Repo")
+	d.Set("slug", "")
+	checkDiags(t, resourceRepositoryUpdate(ctx, d, c))
+	if (d.Id() != id) || (d.Get("slug").(string) != "renamed-repo") || (d.Get("full_name").(string) != testWorkspace+"/renamed-repo") {
+		t.Errorf("rename was not reflected in state: %v", d.State())
+	}
+	if (fb.repository("creme-brulee") != nil) || (fb.repository("renamed-repo") == nil) {
+		t.Errorf("repository was not renamed in place")
+	}
+	d.Set("slug", "custom-slug")
+	checkDiags(t, resourceRepositoryUpdate(ctx, d, c))
+	if (d.Get("slug").(string) != "custom-slug") || (fb.repository("custom-slug")["uuid"] != id) {
+		t.Errorf("explicit slug was not applied: %v", d.State())
+	}
+	explicit := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":   testProjectUuid,
+		"name":         "Some Other Name",
+		"slug":         "custom-slug",
+		"use_existing": true,
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, explicit, c))
+	if explicit.Id() != id {
+		t.Errorf("use_existing did not find the repository by its explicit slug")
+	}
+}
+
+func TestResourceRepositoryEmptySlug(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id": testProjectUuid,
+		"name":       "日本",
+	})
+	diags := resourceRepositoryCreate(ctx, d, c)
+	if !diags.HasError() || (diags[0].Summary != "Unable to derive a slug") || (d.Id() != "") {
+		t.Errorf("expected a name without a slug to fail, got %v", diags)
+	}
+	d.Set("slug", "nihon")
+	checkDiags(t, resourceRepositoryCreate(ctx, d, c))
+	if fb.repository("nihon") == nil {
+		t.Errorf("repository was not created with the explicit slug")
+	}
+}
+
+func TestResourceRepositoryRenamePlan(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id": testProjectUuid,
+		"name":       "My Repo",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, d, c))
+	config := map[string]interface{}{
+		"project_id": testProjectUuid,
+		"name":       "My Repo v2",
+	}
+	diff := testPlan(t, resourceRepository(), d, config, c)
+	if (diff.Attributes["slug"] == nil) || !diff.Attributes["slug"].NewComputed {
+		t.Errorf("derived slug should follow the rename: %v", diff)
+	}
+	config["slug"] = "my-repo"
+	diff = testPlan(t, resourceRepository(), d, config, c)
+	if diff.Attributes["slug"] != nil {
+		t.Errorf("configured slug should be kept on rename: %v", diff.Attributes["slug"])
+	}
+	if diff.Attributes["full_name"] != nil {
+		t.Errorf("full_name should not change with a kept slug: %v", diff.Attributes["full_name"])
+	}
+}
+
+func TestResourceRepositoryMoveProject(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id": testProjectUuid,
+		"name":       "Test Repo",
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, d, c))
+	d.Set("project_id", testOtherProjectUuid)
+	checkDiags(t, resourceRepositoryUpdate(ctx, d, c))
+	moved := fb.repository("test-repo")["project"].(map[string]any)
+	if (moved["key"] != testOtherProjectKey) || (d.Get("project_id").(string) != testOtherProjectUuid) {
+		t.Errorf("repository was not moved: %v", moved)
+	}
+	d.Set("project_id", "{00000000-0000-0000-0000-999999999999}")
+	diags := resourceRepositoryUpdate(ctx, d, c)
+	if !diags.HasError() || (diags[0].Summary != "Repository was not moved") {
+		t.Fatalf("expected an unverified move to fail, got %v", diags)
+	}
+	if d.Get("project_id").(string) != testOtherProjectUuid {
+		t.Errorf("state should keep the actual project, got %s", d.Get("project_id"))
+	}
+	// Moves made outside of Terraform are detected on the next read
+	fb.repository("test-repo")["project"] = map[string]any{"type": "project", "uuid": testProjectUuid, "key": testProjectKey}
+	diags = resourceRepositoryRead(ctx, d, c)
+	checkDiags(t, diags)
+	if (len(diags) != 1) || (diags[0].Summary != "Repository was moved") {
+		t.Errorf("expected read to warn about the move, got %v", diags)
+	}
+	if d.Get("project_id").(string) != testProjectUuid {
+		t.Errorf("read did not detect the project move, got %s", d.Get("project_id"))
+	}
+	if diags = resourceRepositoryRead(ctx, d, c); len(diags) != 0 {
+		t.Errorf("read should not warn once the move is in state, got %v", diags)
+	}
+}
+
+func TestResourceRepositoryDeletionProtection(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"project_id":          testProjectUuid,
+		"name":                "Test Repo",
+		"deletion_protection": true,
+	})
+	checkDiags(t, resourceRepositoryCreate(ctx, d, c))
+	diags := resourceRepositoryDelete(ctx, d, c)
+	if !diags.HasError() || (d.Id() == "") || (fb.repository("test-repo") == nil) {
+		t.Fatalf("protected repository should not be deleted, got %v", diags)
+	}
+	id := d.Id()
+	d.Set("on_destroy", "detach")
+	checkDiags(t, resourceRepositoryDelete(ctx, d, c))
+	if (d.Id() != "") || (fb.repository("test-repo") == nil) {
+		t.Errorf("protected repository should still be detachable")
+	}
+	d.SetId(id)
+	d.Set("deletion_protection", false)
+	checkDiags(t, resourceRepositoryDelete(ctx, d, c))
+	if (d.Id() != "") || (fb.repository("test-repo") == nil) {
+		t.Errorf("detached repository should only be removed from state")
+	}
+}
@@ -0,0 +1,192 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-http-utils/headers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+)
+
+var permissionLevels = []string{"read", "write", "admin"}
+
+func resourceRepositoryUserPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryUserPermissionCreate,
+		ReadContext:   resourceRepositoryUserPermissionRead,
+		UpdateContext: resourceRepositoryUserPermissionUpdate,
+		DeleteContext: resourceRepositoryUserPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRepositoryUserPermissionImport,
+		},
+		Timeouts: defaultTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(permissionLevels, false),
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRepositoryUserPermissionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	repositoryId, userId, err := client.RepositoryUserPermissionDecodeId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	repositoryId, err = resolveRepositoryId(ctx, c, repositoryId)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(repositoryId + client.IdSeparator + userId)
+	return []*schema.ResourceData{d}, nil
+}
+
+var permissionErrorAttributes = map[string]string{
+	"permission": "permission",
+}
+
+func fillRepositoryUserPermission(c *client.RepositoryUserPermission, d *schema.ResourceData) {
+	c.RepositoryId = d.Get("repository_id").(string)
+	c.UserId = d.Get("user_id").(string)
+	c.Permission = d.Get("permission").(string)
+}
+
+func fillResourceDataFromRepositoryUserPermission(c *client.RepositoryUserPermission, d *schema.ResourceData) {
+	d.Set("repository_id", c.RepositoryId)
+	d.Set("user_id", c.UserId)
+	d.Set("permission", c.Permission)
+	if c.User != nil {
+		d.Set("account_id", c.User.AccountId)
+		d.Set("user_uuid", c.User.Uuid)
+		d.Set("display_name", c.User.DisplayName)
+	}
+}
+
+// putRepositoryUserPermission grants a permission, which creates or replaces any permission the user already has
+func putRepositoryUserPermission(ctx context.Context, c *client.Client, p *client.RepositoryUserPermission) (*client.RepositoryUserPermission, error) {
+	buf := bytes.Buffer{}
+	err := json.NewEncoder(&buf).Encode(p)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(client.RepositoryUserPermissionPathGet, c.Workspace, p.RepositoryId, p.UserId)
+	requestHeaders := http.Header{
+		headers.ContentType: []string{client.ApplicationJson},
+	}
+	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
+	if err != nil {
+		return nil, err
+	}
+	retVal := &client.RepositoryUserPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return nil, err
+	}
+	retVal.RepositoryId = p.RepositoryId
+	retVal.UserId = p.UserId
+	return retVal, nil
+}
+
+func resourceRepositoryUserPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	newPermission := client.RepositoryUserPermission{}
+	fillRepositoryUserPermission(&newPermission, d)
+	retVal, err := putRepositoryUserPermission(ctx, c, &newPermission)
+	if err != nil {
+		d.SetId("")
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromRepositoryUserPermission(retVal, d)
+	d.SetId(retVal.RepositoryUserPermissionEncodeId())
+	return diags
+}
+
+func resourceRepositoryUserPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	repositoryId, userId, err := client.RepositoryUserPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.RepositoryUserPermissionPathGet, c.Workspace, repositoryId, userId)
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		d.SetId("")
+		re := err.(*client.RequestError)
+		if re.StatusCode == http.StatusNotFound {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	retVal := &client.RepositoryUserPermission{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	retVal.RepositoryId = repositoryId
+	retVal.UserId = userId
+	fillResourceDataFromRepositoryUserPermission(retVal, d)
+	return diags
+}
+
+func resourceRepositoryUserPermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	upPermission := client.RepositoryUserPermission{}
+	fillRepositoryUserPermission(&upPermission, d)
+	retVal, err := putRepositoryUserPermission(ctx, c, &upPermission)
+	if err != nil {
+		return diagFromRequestError(err, permissionErrorAttributes)
+	}
+	fillResourceDataFromRepositoryUserPermission(retVal, d)
+	return diags
+}
+
+func resourceRepositoryUserPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*client.Client)
+	repositoryId, userId, err := client.RepositoryUserPermissionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requestPath := fmt.Sprintf(client.RepositoryUserPermissionPathGet, c.Workspace, repositoryId, userId)
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
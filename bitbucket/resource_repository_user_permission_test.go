@@ -0,0 +1,88 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	testAccountId = "557058:00000000-0000-0000-0000-00000000aaaa"
+	testUserUuid  = "{00000000-0000-0000-0000-00000000aaaa}"
+)
+
+func TestResourceRepositoryUserPermission(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRepository + `
+resource "bitbucket_repository_user_permission" "Perm" {
+  repository_id = bitbucket_repository.Repo.id
+  user_id       = "557058:00000000-0000-0000-0000-00000000aaaa"
+  permission    = "write"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bitbucket_repository_user_permission.Perm", "permission", "write"),
+					resource.TestCheckResourceAttr("bitbucket_repository_user_permission.Perm", "user_uuid", testUserUuid),
+					resource.TestCheckResourceAttr("bitbucket_repository_user_permission.Perm", "display_name", "Alice"),
+				),
+			},
+			{
+				ResourceName:      "bitbucket_repository_user_permission.Perm",
+				ImportState:       true,
+				ImportStateId:     "test-repo:" + testAccountId,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceRepositoryUserPermissionLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	r := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, r, c))
+	d := schema.TestResourceDataRaw(t, resourceRepositoryUserPermission().Schema, map[string]interface{}{
+		"repository_id": r.Id(),
+		"user_id":       testAccountId,
+		"permission":    "write",
+	})
+	checkDiags(t, resourceRepositoryUserPermissionCreate(ctx, d, c))
+	if (d.Id() != r.Id()+":"+testAccountId) || (d.Get("user_uuid").(string) != testUserUuid) {
+		t.Fatalf("unexpected permission: %s, %v", d.Id(), d.State())
+	}
+	// Changes made in the UI are detected
+	fb.collections[r.Id()+"/permissions-config/users"][0]["permission"] = "admin"
+	checkDiags(t, resourceRepositoryUserPermissionRead(ctx, d, c))
+	if d.Get("permission").(string) != "admin" {
+		t.Errorf("drift was not detected, got %s", d.Get("permission"))
+	}
+	d.Set("permission", "read")
+	checkDiags(t, resourceRepositoryUserPermissionUpdate(ctx, d, c))
+	if fb.collections[r.Id()+"/permissions-config/users"][0]["permission"] != "read" {
+		t.Errorf("permission was not updated")
+	}
+	imported := schema.TestResourceDataRaw(t, resourceRepositoryUserPermission().Schema, map[string]interface{}{})
+	imported.SetId("test-repo:" + testAccountId)
+	_, err := resourceRepositoryUserPermissionImport(ctx, imported, c)
+	if (err != nil) || (imported.Id() != d.Id()) {
+		t.Errorf("unexpected import: %s, %v", imported.Id(), err)
+	}
+	checkDiags(t, resourceRepositoryUserPermissionDelete(ctx, d, c))
+	if len(fb.collections[r.Id()+"/permissions-config/users"]) != 0 {
+		t.Errorf("permission was not revoked")
+	}
+	d.SetId(imported.Id())
+	checkDiags(t, resourceRepositoryUserPermissionRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("revoked permission should be removed from state")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -22,8 +23,9 @@ func resourceRestriction() *schema.Resource {
 		UpdateContext: resourceRestrictionUpdate,
 		DeleteContext: resourceRestrictionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRestrictionImport,
 		},
+		Timeouts:      defaultTimeouts(),
 		CustomizeDiff: resourceRestrictionDiff,
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
@@ -32,11 +34,9 @@ func resourceRestriction() *schema.Resource {
 				ForceNew: true,
 			},
 			"kind": {
-				Type:     schema.TypeString,
-				Required: true,
-				// TODO: Implement all kinds
-				//ValidateFunc: validation.StringInSlice([]string{"push", "delete", "force", "restrict_merges", "require_tasks_to_be_completed", "require_approvals_to_merge", "require_review_group_approvals_to_merge", "require_default_reviewer_approvals_to_merge", "require_no_changes_requested", "require_passing_builds_to_merge", "require_commits_behind", "reset_pullrequest_approvals_on_change", "smart_reset_pullrequest_approvals", "reset_pullrequest_changes_requested_on_change", "require_all_dependencies_merged", "enforce_merge_checks", "allow_auto_merge_when_builds_pass", "require_all_comments_resolved"}, false),
-				ValidateFunc: validation.StringInSlice([]string{"require_approvals_to_merge", "require_default_reviewer_approvals_to_merge", "require_passing_builds_to_merge", "require_commits_behind"}, false),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(restrictionKinds, false),
 			},
 			"branch_match_kind": {
 				Type:         schema.TypeString,
@@ -56,6 +56,16 @@ func resourceRestriction() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"users": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"groups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"use_existing": {
 				Type:             schema.TypeBool,
 				Optional:         true,
@@ -70,6 +80,14 @@ func resourceRestriction() *schema.Resource {
 	}
 }
 
+var restrictionKinds = []string{"push", "delete", "force", "restrict_merges", "require_tasks_to_be_completed", "require_approvals_to_merge", "require_review_group_approvals_to_merge", "require_default_reviewer_approvals_to_merge", "require_no_changes_requested", "require_passing_builds_to_merge", "require_commits_behind", "reset_pullrequest_approvals_on_change", "smart_reset_pullrequest_approvals", "reset_pullrequest_changes_requested_on_change", "require_all_dependencies_merged", "enforce_merge_checks", "allow_auto_merge_when_builds_pass", "require_all_comments_resolved"}
+
+// The kinds that only let the listed users and groups through, and everyone else is blocked
+var restrictionAllowlistKinds = []string{"push", "restrict_merges"}
+
+// The kinds that are a count rather than a simple on/off switch
+var restrictionValueKinds = []string{"require_approvals_to_merge", "require_review_group_approvals_to_merge", "require_default_reviewer_approvals_to_merge", "require_passing_builds_to_merge", "require_commits_behind"}
+
 func resourceRestrictionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
 	requiresBranchType := []string{"branching_model"}
 	_, ok := d.GetOk("branch_type")
@@ -81,14 +99,49 @@ func resourceRestrictionDiff(ctx context.Context, d *schema.ResourceDiff, m inte
 	if slices.Contains(requiresPattern, d.Get("branch_match_kind").(string)) && !ok {
 		return fmt.Errorf("pattern must be set when branch_match_kind is one of: %v", requiresPattern)
 	}
-	requiresValue := []string{"require_approvals_to_merge", "require_default_reviewer_approvals_to_merge", "require_passing_builds_to_merge", "require_commits_behind"}
 	_, ok = d.GetOk("value")
-	if slices.Contains(requiresValue, d.Get("kind").(string)) && !ok {
-		return fmt.Errorf("value must be set when kind is one of: %v", requiresValue)
+	requiresValue := slices.Contains(restrictionValueKinds, d.Get("kind").(string))
+	if requiresValue && !ok {
+		return fmt.Errorf("value must be set when kind is one of: %v", restrictionValueKinds)
+	}
+	if !requiresValue && ok {
+		return fmt.Errorf("value must only be set when kind is one of: %v", restrictionValueKinds)
+	}
+	if !slices.Contains(restrictionAllowlistKinds, d.Get("kind").(string)) {
+		for _, key := range []string{"users", "groups"} {
+			_, ok = d.GetOk(key)
+			if ok {
+				return fmt.Errorf("%s must only be set when kind is one of: %v", key, restrictionAllowlistKinds)
+			}
+		}
 	}
 	return nil
 }
 
+func resourceRestrictionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	repositoryId, id, err := client.RestrictionDecodeId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	repositoryId, err = resolveRepositoryId(ctx, c, repositoryId)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(repositoryId + client.IdSeparator + id)
+	return []*schema.ResourceData{d}, nil
+}
+
+var restrictionErrorAttributes = map[string]string{
+	"kind":              "kind",
+	"branch_match_kind": "branch_match_kind",
+	"branch_type":       "branch_type",
+	"pattern":           "pattern",
+	"value":             "value",
+	"users":             "users",
+	"groups":            "groups",
+}
+
 func fillRestriction(c *client.Restriction, d *schema.ResourceData) {
 	c.RepositoryId = d.Get("repository_id").(string)
 	c.Kind = d.Get("kind").(string)
@@ -105,6 +158,24 @@ func fillRestriction(c *client.Restriction, d *schema.ResourceData) {
 	if ok {
 		c.Value = value.(int)
 	}
+	if slices.Contains(restrictionAllowlistKinds, c.Kind) {
+		users := []client.Account{}
+		for _, user := range d.Get("users").(*schema.Set).List() {
+			// Users may be given by account id or UUID
+			userId := user.(string)
+			if strings.HasPrefix(userId, "{") {
+				users = append(users, client.Account{Uuid: userId})
+			} else {
+				users = append(users, client.Account{AccountId: userId})
+			}
+		}
+		c.Users = &users
+		groups := []client.Group{}
+		for _, group := range d.Get("groups").(*schema.Set).List() {
+			groups = append(groups, client.Group{Slug: group.(string)})
+		}
+		c.Groups = &groups
+	}
 	c.UseExisting = d.Get("use_existing").(bool)
 }
 
@@ -115,6 +186,25 @@ func fillResourceDataFromRestriction(c *client.Restriction, d *schema.ResourceDa
 	d.Set("branch_type", c.BranchType)
 	d.Set("pattern", c.Pattern)
 	d.Set("value", c.Value)
+	users := []string{}
+	if c.Users != nil {
+		declared := map[string]string{}
+		for _, user := range d.Get("users").(*schema.Set).List() {
+			declared[user.(string)] = ""
+		}
+		for _, user := range *c.Users {
+			userId, _ := declaredUserId(&user, declared)
+			users = append(users, userId)
+		}
+	}
+	d.Set("users", users)
+	groups := []string{}
+	if c.Groups != nil {
+		for _, group := range *c.Groups {
+			groups = append(groups, group.Slug)
+		}
+	}
+	d.Set("groups", groups)
 	d.Set("restriction_id", c.Id)
 	d.Set("use_existing", c.UseExisting)
 }
@@ -127,20 +217,13 @@ func resourceRestrictionCreate(ctx context.Context, d *schema.ResourceData, m in
 	var retVal *client.Restriction = nil
 	if newRestriction.UseExisting {
 		// Try to find an existing restriction with the given kind, branch_match_kind, branch_type, and pattern and return it if found
-		// TODO: Paginate through results if many restrictions exist
 		requestPath := fmt.Sprintf(client.RestrictionPath, c.Workspace, newRestriction.RepositoryId)
-		body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(err)
-		}
-		retVals := &client.RestrictionCollection{}
-		err = json.NewDecoder(body).Decode(retVals)
+		retVals, err := client.HttpRequestAll[client.Restriction](ctx, c, false, requestPath, nil)
 		if err != nil {
 			d.SetId("")
 			return diag.FromErr(err)
 		}
-		for _, r := range retVals.Values {
+		for _, r := range retVals {
 			if (r.Kind == newRestriction.Kind) && (r.BranchMatchKind == newRestriction.BranchMatchKind) && (r.BranchType == newRestriction.BranchType) && (r.Pattern == newRestriction.Pattern) {
 				retVal = &r
 				break
@@ -161,7 +244,7 @@ func resourceRestrictionCreate(ctx context.Context, d *schema.ResourceData, m in
 		body, err := c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 		if err != nil {
 			d.SetId("")
-			return diag.FromErr(err)
+			return diagFromRequestError(err, restrictionErrorAttributes)
 		}
 		retVal = &client.Restriction{}
 		err = json.NewDecoder(body).Decode(retVal)
@@ -179,7 +262,10 @@ func resourceRestrictionCreate(ctx context.Context, d *schema.ResourceData, m in
 func resourceRestrictionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.RestrictionDecodeId(d.Id())
+	repositoryId, id, err := client.RestrictionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	requestPath := fmt.Sprintf(client.RestrictionPathGet, c.Workspace, repositoryId, id)
 	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
@@ -204,11 +290,14 @@ func resourceRestrictionRead(ctx context.Context, d *schema.ResourceData, m inte
 func resourceRestrictionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.RestrictionDecodeId(d.Id())
+	repositoryId, id, err := client.RestrictionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	buf := bytes.Buffer{}
 	upRestriction := client.Restriction{}
 	fillRestriction(&upRestriction, d)
-	err := json.NewEncoder(&buf).Encode(upRestriction)
+	err = json.NewEncoder(&buf).Encode(upRestriction)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -218,7 +307,7 @@ func resourceRestrictionUpdate(ctx context.Context, d *schema.ResourceData, m in
 	}
 	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, restrictionErrorAttributes)
 	}
 	retVal := &client.Restriction{}
 	err = json.NewDecoder(body).Decode(retVal)
@@ -234,9 +323,12 @@ func resourceRestrictionUpdate(ctx context.Context, d *schema.ResourceData, m in
 func resourceRestrictionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.RestrictionDecodeId(d.Id())
+	repositoryId, id, err := client.RestrictionDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	requestPath := fmt.Sprintf(client.RestrictionPathGet, c.Workspace, repositoryId, id)
-	_, err := c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
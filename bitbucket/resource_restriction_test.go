@@ -0,0 +1,175 @@
+package bitbucket
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testConfigRestriction(value int) string {
+	return testConfigRepository + `
+resource "bitbucket_restriction" "Restrict" {
+  repository_id     = bitbucket_repository.Repo.id
+  kind              = "require_approvals_to_merge"
+  branch_match_kind = "glob"
+  pattern           = "release/*"
+  value             = ` + strconv.Itoa(value) + `
+}
+`
+}
+
+func TestResourceRestriction(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigRestriction(1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_restriction.Restrict", "restriction_id"),
+					resource.TestCheckResourceAttr("bitbucket_restriction.Restrict", "kind", "require_approvals_to_merge"),
+					resource.TestCheckResourceAttr("bitbucket_restriction.Restrict", "pattern", "release/*"),
+					resource.TestCheckResourceAttr("bitbucket_restriction.Restrict", "value", "1"),
+				),
+			},
+			{
+				Config: testConfigRestriction(2),
+				Check:  resource.TestCheckResourceAttr("bitbucket_restriction.Restrict", "value", "2"),
+			},
+			{
+				ResourceName:            "bitbucket_restriction.Restrict",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
+
+func TestResourceRestrictionLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	raw := map[string]interface{}{
+		"repository_id":     repository.Id(),
+		"kind":              "require_passing_builds_to_merge",
+		"branch_match_kind": "branching_model",
+		"branch_type":       "production",
+		"value":             1,
+	}
+	for _, branchType := range []string{"feature", "bugfix", "production"} {
+		raw["branch_type"] = branchType
+		d := schema.TestResourceDataRaw(t, resourceRestriction().Schema, raw)
+		checkDiags(t, resourceRestrictionCreate(ctx, d, c))
+	}
+	raw["use_existing"] = true
+	d := schema.TestResourceDataRaw(t, resourceRestriction().Schema, raw)
+	checkDiags(t, resourceRestrictionCreate(ctx, d, c))
+	if count := len(fb.collections[repository.Id()+"/branch-restrictions"]); count != 3 {
+		t.Errorf("use_existing created a duplicate, %d restrictions exist", count)
+	}
+	d.Set("value", 3)
+	checkDiags(t, resourceRestrictionUpdate(ctx, d, c))
+	checkDiags(t, resourceRestrictionRead(ctx, d, c))
+	if d.Get("value").(int) != 3 {
+		t.Errorf("restriction was not updated: %v", d.State())
+	}
+	imported := schema.TestResourceDataRaw(t, resourceRestriction().Schema, map[string]interface{}{})
+	imported.SetId("test-repo:" + strconv.Itoa(d.Get("restriction_id").(int)))
+	_, err := resourceRestrictionImport(ctx, imported, c)
+	if (err != nil) || (imported.Id() != d.Id()) {
+		t.Errorf("slug import resolved to %q, %v; expected %q", imported.Id(), err, d.Id())
+	}
+	id := d.Id()
+	checkDiags(t, resourceRestrictionDelete(ctx, d, c))
+	d.SetId(id)
+	checkDiags(t, resourceRestrictionRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("deleted restriction should be removed from state")
+	}
+}
+
+func TestResourceRestrictionKinds(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	r := resourceRestriction()
+	for _, kind := range restrictionKinds {
+		raw := map[string]interface{}{
+			"repository_id":     repository.Id(),
+			"kind":              kind,
+			"branch_match_kind": "glob",
+			"pattern":           "main",
+		}
+		_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), c)
+		requiresValue := slices.Contains(restrictionValueKinds, kind)
+		if requiresValue != (err != nil) {
+			t.Errorf("kind %s without value gave %v", kind, err)
+		}
+		raw["value"] = 2
+		_, err = r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), c)
+		if requiresValue != (err == nil) {
+			t.Errorf("kind %s with value gave %v", kind, err)
+		}
+		if !requiresValue {
+			delete(raw, "value")
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		checkDiags(t, resourceRestrictionCreate(ctx, d, c))
+		checkDiags(t, resourceRestrictionRead(ctx, d, c))
+		if d.Get("kind").(string) != kind {
+			t.Errorf("restriction was read back as %v", d.State())
+		}
+	}
+}
+
+func TestResourceRestrictionAllowlist(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	raw := map[string]interface{}{
+		"repository_id":     repository.Id(),
+		"kind":              "push",
+		"branch_match_kind": "glob",
+		"pattern":           "main",
+		"users":             []interface{}{fakeUsers[0]["account_id"], fakeUsers[1]["uuid"]},
+		"groups":            []interface{}{"administrators"},
+	}
+	d := schema.TestResourceDataRaw(t, resourceRestriction().Schema, raw)
+	checkDiags(t, resourceRestrictionCreate(ctx, d, c))
+	checkDiags(t, resourceRestrictionRead(ctx, d, c))
+	users := d.Get("users").(*schema.Set)
+	if (users.Len() != 2) || !users.Contains(fakeUsers[0]["account_id"]) || !users.Contains(fakeUsers[1]["uuid"]) {
+		t.Errorf("users were not read back as declared: %v", users.List())
+	}
+	if groups := d.Get("groups").(*schema.Set); (groups.Len() != 1) || !groups.Contains("administrators") {
+		t.Errorf("groups were not read back: %v", groups.List())
+	}
+	stored := fb.collections[repository.Id()+"/branch-restrictions"][0]
+	if len(stored["users"].([]any)) != 2 {
+		t.Errorf("users were not sent: %v", stored)
+	}
+	d.Set("users", []interface{}{})
+	checkDiags(t, resourceRestrictionUpdate(ctx, d, c))
+	if len(fb.collections[repository.Id()+"/branch-restrictions"][0]["users"].([]any)) != 0 {
+		t.Errorf("users were not cleared: %v", fb.collections[repository.Id()+"/branch-restrictions"][0])
+	}
+	raw["kind"] = "require_tasks_to_be_completed"
+	_, err := resourceRestriction().Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), c)
+	if err == nil {
+		t.Errorf("users and groups should only be allowed for %v", restrictionAllowlistKinds)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-http-utils/headers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -20,8 +21,9 @@ func resourceWebhook() *schema.Resource {
 		UpdateContext: resourceWebhookUpdate,
 		DeleteContext: resourceWebhookDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceWebhookImport,
 		},
+		Timeouts: defaultTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"repository_id": {
 				Type:     schema.TypeString,
@@ -62,6 +64,57 @@ func resourceWebhook() *schema.Resource {
 	}
 }
 
+func resourceWebhookImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	repository, title, found := strings.Cut(d.Id(), "/")
+	if !found {
+		repositoryId, id, err := client.WebhookDecodeId(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("%w, or repository_slug/webhook_title", err)
+		}
+		repositoryId, err = resolveRepositoryId(ctx, c, repositoryId)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(repositoryId + client.IdSeparator + id)
+		return []*schema.ResourceData{d}, nil
+	}
+	if (repository == "") || (title == "") {
+		return nil, fmt.Errorf("invalid id %q: expected repository_slug/webhook_title", d.Id())
+	}
+	repositoryId, err := resolveRepositoryId(ctx, c, repository)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := fmt.Sprintf(client.WebhookPath, c.Workspace, repositoryId)
+	retVals, err := client.HttpRequestAll[client.Webhook](ctx, c, false, requestPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var retVal *client.Webhook = nil
+	for _, wh := range retVals {
+		if wh.Description == title {
+			if retVal != nil {
+				return nil, fmt.Errorf("more than one webhook titled %q found in repository %q, import it by uuid instead", title, repository)
+			}
+			retVal = &wh
+		}
+	}
+	if retVal == nil {
+		return nil, fmt.Errorf("no webhook titled %q found in repository %q", title, repository)
+	}
+	retVal.RepositoryId = repositoryId
+	d.SetId(retVal.WebhookEncodeId())
+	return []*schema.ResourceData{d}, nil
+}
+
+var webhookErrorAttributes = map[string]string{
+	"url":         "url",
+	"events":      "events",
+	"description": "title",
+	"active":      "is_active",
+}
+
 func fillWebhook(c *client.Webhook, d *schema.ResourceData) {
 	c.RepositoryId = d.Get("repository_id").(string)
 	c.Url = d.Get("url").(string)
@@ -92,20 +145,13 @@ func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interf
 	var retVal *client.Webhook = nil
 	if newWebhook.UseExisting {
 		// Try to find an existing webhook with the given url and return it if found
-		// TODO: Paginate through results if many webhooks exist
 		requestPath := fmt.Sprintf(client.WebhookPath, c.Workspace, newWebhook.RepositoryId)
-		body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(err)
-		}
-		retVals := &client.WebhookCollection{}
-		err = json.NewDecoder(body).Decode(retVals)
+		retVals, err := client.HttpRequestAll[client.Webhook](ctx, c, false, requestPath, nil)
 		if err != nil {
 			d.SetId("")
 			return diag.FromErr(err)
 		}
-		for _, wh := range retVals.Values {
+		for _, wh := range retVals {
 			if wh.Url == newWebhook.Url {
 				retVal = &wh
 				break
@@ -126,7 +172,7 @@ func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interf
 		body, err := c.HttpRequest(ctx, false, http.MethodPost, requestPath, nil, requestHeaders, &buf)
 		if err != nil {
 			d.SetId("")
-			return diag.FromErr(err)
+			return diagFromRequestError(err, webhookErrorAttributes)
 		}
 		retVal = &client.Webhook{}
 		err = json.NewDecoder(body).Decode(retVal)
@@ -144,7 +190,10 @@ func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interf
 func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.WebhookDecodeId(d.Id())
+	repositoryId, id, err := client.WebhookDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	requestPath := fmt.Sprintf(client.WebhookPathGet, c.Workspace, repositoryId, id)
 	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
@@ -169,11 +218,14 @@ func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interfac
 func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.WebhookDecodeId(d.Id())
+	repositoryId, id, err := client.WebhookDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	buf := bytes.Buffer{}
 	upWebhook := client.Webhook{}
 	fillWebhook(&upWebhook, d)
-	err := json.NewEncoder(&buf).Encode(upWebhook)
+	err = json.NewEncoder(&buf).Encode(upWebhook)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -183,7 +235,7 @@ func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	body, err := c.HttpRequest(ctx, false, http.MethodPut, requestPath, nil, requestHeaders, &buf)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromRequestError(err, webhookErrorAttributes)
 	}
 	retVal := &client.Webhook{}
 	err = json.NewDecoder(body).Decode(retVal)
@@ -198,9 +250,12 @@ func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interf
 func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	c := m.(*client.Client)
-	repositoryId, id := client.WebhookDecodeId(d.Id())
+	repositoryId, id, err := client.WebhookDecodeId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	requestPath := fmt.Sprintf(client.WebhookPathGet, c.Workspace, repositoryId, id)
-	_, err := c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
+	_, err = c.HttpRequest(ctx, false, http.MethodDelete, requestPath, nil, nil, &bytes.Buffer{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,98 @@
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testConfigWebhook(active bool) string {
+	isActive := "true"
+	if !active {
+		isActive = "false"
+	}
+	return testConfigRepository + `
+resource "bitbucket_webhook" "Hook" {
+  repository_id = bitbucket_repository.Repo.id
+  url           = "https://example.com/hook"
+  title         = "PR_CLEANUP"
+  events        = ["pullrequest:fulfilled", "pullrequest:rejected"]
+  is_active     = ` + isActive + `
+}
+`
+}
+
+func TestResourceWebhook(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	fb.configureEnv(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          testPreCheck(t),
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigWebhook(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitbucket_webhook.Hook", "uuid"),
+					resource.TestCheckResourceAttr("bitbucket_webhook.Hook", "title", "PR_CLEANUP"),
+					resource.TestCheckResourceAttr("bitbucket_webhook.Hook", "events.#", "2"),
+					resource.TestCheckResourceAttr("bitbucket_webhook.Hook", "is_active", "true"),
+				),
+			},
+			{
+				Config: testConfigWebhook(false),
+				Check:  resource.TestCheckResourceAttr("bitbucket_webhook.Hook", "is_active", "false"),
+			},
+			{
+				ResourceName:            "bitbucket_webhook.Hook",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+			{
+				ResourceName:            "bitbucket_webhook.Hook",
+				ImportState:             true,
+				ImportStateId:           "test-repo/PR_CLEANUP",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_existing"},
+			},
+		},
+	})
+}
+
+func TestResourceWebhookLifecycle(t *testing.T) {
+	fb := newFakeBitbucket(t)
+	c := fb.client(t)
+	ctx := context.Background()
+	repository := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{"project_id": testProjectUuid, "name": "Test Repo"})
+	checkDiags(t, resourceRepositoryCreate(ctx, repository, c))
+	for _, hookUrl := range []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"} {
+		d := schema.TestResourceDataRaw(t, resourceWebhook().Schema, map[string]interface{}{"repository_id": repository.Id(), "url": hookUrl, "title": hookUrl, "events": []interface{}{"repo:push"}})
+		checkDiags(t, resourceWebhookCreate(ctx, d, c))
+	}
+	d := schema.TestResourceDataRaw(t, resourceWebhook().Schema, map[string]interface{}{"repository_id": repository.Id(), "url": "https://example.com/c", "events": []interface{}{"repo:push"}, "use_existing": true})
+	checkDiags(t, resourceWebhookCreate(ctx, d, c))
+	if count := len(fb.collections[repository.Id()+"/hooks"]); count != 3 {
+		t.Errorf("use_existing created a duplicate, %d webhooks exist", count)
+	}
+	d.Set("is_active", false)
+	checkDiags(t, resourceWebhookUpdate(ctx, d, c))
+	checkDiags(t, resourceWebhookRead(ctx, d, c))
+	if d.Get("is_active").(bool) {
+		t.Errorf("webhook was not updated: %v", d.State())
+	}
+	imported := schema.TestResourceDataRaw(t, resourceWebhook().Schema, map[string]interface{}{})
+	imported.SetId("test-repo/https://example.com/c")
+	_, err := resourceWebhookImport(ctx, imported, c)
+	if (err != nil) || (imported.Id() != d.Id()) {
+		t.Errorf("friendly import resolved to %q, %v; expected %q", imported.Id(), err, d.Id())
+	}
+	id := d.Id()
+	checkDiags(t, resourceWebhookDelete(ctx, d, c))
+	d.SetId(id)
+	checkDiags(t, resourceWebhookRead(ctx, d, c))
+	if d.Id() != "" {
+		t.Errorf("deleted webhook should be removed from state")
+	}
+}
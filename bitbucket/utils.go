@@ -1,9 +1,20 @@
 package bitbucket
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scastria/terraform-provider-bitbucket/bitbucket/client"
+	"golang.org/x/text/unicode/norm"
 )
 
 func convertSetToArray(set *schema.Set) []string {
@@ -19,6 +30,103 @@ func convertSetToArray(set *schema.Set) []string {
 	return retVal
 }
 
+// convertNameToSlug derives a repository slug from its name the way Bitbucket does: accents are stripped, anything
+// other than letters, digits, dots and underscores becomes a single dash, and surrounding dashes are trimmed
 func convertNameToSlug(name string) string {
-	return strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	slug := strings.Builder{}
+	dash := false
+	for _, r := range norm.NFKD.String(strings.ToLower(name)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case ((r >= 'a') && (r <= 'z')) || ((r >= '0') && (r <= '9')) || (r == '.') || (r == '_'):
+			if dash && (slug.Len() > 0) {
+				slug.WriteRune('-')
+			}
+			slug.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return slug.String()
+}
+
+// slugFromName derives a slug from a name and fails on names without a single usable character, since an empty slug
+// would address the list of repositories instead of one of them
+func slugFromName(name string, attribute string, hint string) (string, diag.Diagnostics) {
+	slug := convertNameToSlug(name)
+	if slug == "" {
+		return "", diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to derive a slug",
+			Detail:        fmt.Sprintf("No slug can be derived from %q as it has no letters a-z, digits, dots or underscores.  %s", name, hint),
+			AttributePath: cty.GetAttrPath(attribute),
+		}}
+	}
+	return slug, nil
+}
+
+// bbqlString quotes a value for use in a Bitbucket query language filter
+func bbqlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func defaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(10 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(10 * time.Minute),
+		Delete: schema.DefaultTimeout(10 * time.Minute),
+	}
+}
+
+// diagFromRequestError reports each field error from Bitbucket against the argument it came from, using attributes
+// to translate API field names into schema attribute names
+func diagFromRequestError(err error, attributes map[string]string) diag.Diagnostics {
+	re, ok := err.(*client.RequestError)
+	if !ok || (len(re.Fields) == 0) {
+		return diag.FromErr(err)
+	}
+	summary := re.Message
+	if summary == "" {
+		summary = "Bitbucket rejected the request"
+	}
+	var diags diag.Diagnostics
+	for _, field := range re.FieldNames() {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   strings.Join(re.Fields[field], "\n"),
+		}
+		attribute, ok := attributes[field]
+		if ok {
+			d.AttributePath = cty.GetAttrPath(attribute)
+		} else {
+			d.Detail = field + ": " + d.Detail
+		}
+		if re.RequestId != "" {
+			d.Detail += "\n(Request Id: " + re.RequestId + ")"
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// resolveRepositoryId turns a repository slug into the UUID used as repository_id, leaving UUIDs untouched
+func resolveRepositoryId(ctx context.Context, c *client.Client, repository string) (string, error) {
+	if strings.HasPrefix(repository, "{") {
+		return repository, nil
+	}
+	requestPath := fmt.Sprintf(client.RepositoryPath, c.Workspace, repository)
+	body, err := c.HttpRequest(ctx, false, http.MethodGet, requestPath, nil, nil, &bytes.Buffer{})
+	if err != nil {
+		return "", err
+	}
+	retVal := &client.Repository{}
+	err = json.NewDecoder(body).Decode(retVal)
+	if err != nil {
+		return "", err
+	}
+	return retVal.Uuid, nil
 }
@@ -0,0 +1,43 @@
+package bitbucket
+
+import "testing"
+
+func TestConvertNameToSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"My Repo", "my-repo"},
+		{"my-repo", "my-repo"},
+		{"  Leading and trailing  ", "leading-and-trailing"},
+		{"Punctuation! (and) [brackets]?", "punctuation-and-brackets"},
+		{"Keeps_underscores.and.dots", "keeps_underscores.and.dots"},
+		{"Multiple   ---   dashes", "multiple-dashes"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"Ünïcödé Ñame", "unicode-name"},
+		{"日本 repo", "repo"},
+		{"日本", ""},
+		{"!!!", ""},
+	}
+	for _, test := range tests {
+		actual := convertNameToSlug(test.name)
+		if actual != test.expected {
+			t.Errorf("convertNameToSlug(%q) = %q; expected %q", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestBbqlString(t *testing.T) {
+	tests := map[string]string{
+		`plain`:          `"plain"`,
+		`say "hi"`:       `"say \"hi\""`,
+		`back\slash`:     `"back\\slash"`,
+		`both \"quoted"`: `"both \\\"quoted\""`,
+	}
+	for value, expected := range tests {
+		actual := bbqlString(value)
+		if actual != expected {
+			t.Errorf("bbqlString(%q) = %s; expected %s", value, actual, expected)
+		}
+	}
+}